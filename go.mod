@@ -1 +1,3 @@
-module "github.com/foreverNP/calmet"
+module github.com/foreverNP/calmet
+
+go 1.27.1
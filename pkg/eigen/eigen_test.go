@@ -0,0 +1,242 @@
+package eigen
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	e = 1e-10
+)
+
+var (
+	R = [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 10},
+		{1, 0, 1},
+	}
+)
+
+// maxDifference максимальная по модулю разность элементов матриц
+func maxDifference(A, B [][]float64) float64 {
+	diff := 0.0
+	for i := range A {
+		diff = math.Max(diff, tools.MaxAbsoluteDifference(A[i], B[i]))
+	}
+
+	return diff
+}
+
+func TestSVD(t *testing.T) {
+	for _, A := range [][][]float64{R, tools.TransposeMatrix(R)} {
+		for _, mode := range []SVDMode{SVDFull, SVDThin} {
+			U, S, Vt := SVD(A, e, mode)
+
+			k := len(U[0])
+			Sigma := make([][]float64, k)
+			for i := range Sigma {
+				Sigma[i] = make([]float64, len(Vt))
+				if i < len(S) && i < len(Vt) {
+					Sigma[i][i] = S[i]
+				}
+			}
+
+			if diff := maxDifference(A, tools.MultiplyMatrices(tools.MultiplyMatrices(U, Sigma), Vt)); diff > e*100 {
+				t.Errorf("mode %v: A != U Σ Vᵀ, difference: %v", mode, diff)
+			}
+			if diff := maxDifference(tools.MultiplyMatrices(tools.TransposeMatrix(U), U), tools.IdentityMatrix(k)); diff > e*100 {
+				t.Errorf("mode %v: U is not orthonormal, difference: %v", mode, diff)
+			}
+			for i := 1; i < len(S); i++ {
+				if S[i] > S[i-1] {
+					t.Errorf("singular values are not sorted: %v", S)
+				}
+			}
+		}
+	}
+
+	// Последний проход не выполняет вращений: косинусы углов между столбцами не превосходят eps
+	var steps observer.Recorder
+	_, S, _, _ := SVDContext(context.Background(), R, e, SVDValues, &steps)
+	last := steps.Steps[len(steps.Steps)-1]
+	if last.N != len(steps.Steps) || last.Error > e || math.Abs(last.Estimate-S[0]) > 1e-6*S[0] {
+		t.Errorf("unexpected last sweep: %+v, singular values: %v", last, S)
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	// Матрица ранга 1: A⁺ = Aᵀ / ||A||²_F
+	A := [][]float64{
+		{1, 2},
+		{2, 4},
+		{3, 6},
+	}
+
+	if rank := Rank(A, e); rank != 1 {
+		t.Errorf("expected rank: %v, got: %v", 1, rank)
+	}
+	if cond := Cond(A, e); cond < 1e12 {
+		t.Errorf("expected ill-conditioned matrix, got cond: %v", cond)
+	}
+	if diff := maxDifference(PseudoInverse(A, e), tools.MultiplyMatrixByScalar(tools.TransposeMatrix(A), 1.0/70)); diff > e {
+		t.Errorf("unexpected pseudoinverse, difference: %v", diff)
+	}
+}
+
+func TestGeneralizedJacobiMethod(t *testing.T) {
+	// Жесткость и масса системы из трех пружин
+	K := [][]float64{
+		{2, -1, 0},
+		{-1, 2, -1},
+		{0, -1, 1},
+	}
+	M := [][]float64{
+		{2, 0, 0},
+		{0, 1, 0.5},
+		{0, 0.5, 3},
+	}
+
+	var steps observer.Recorder
+	X, _, errors, counter, err := GeneralizedJacobiMethodContext(context.Background(), K, M, e, &steps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(steps.Steps) != counter {
+		t.Errorf("expected %d observed iterations, got: %d", counter, len(steps.Steps))
+	}
+
+	// Критерий остановки метода Якоби - сумма квадратов внедиагональных элементов,
+	// поэтому невязка имеет порядок sqrt(eps)
+	for i, r := range errors {
+		if r > math.Sqrt(e)*10 {
+			t.Errorf("eigenpair %d: residual %v", i, r)
+		}
+	}
+
+	// Векторы должны быть M-ортонормированы: X M Xᵀ = I
+	if diff := maxDifference(tools.MultiplyMatrices(tools.MultiplyMatrices(X, M), tools.TransposeMatrix(X)), tools.IdentityMatrix(3)); diff > e*100 {
+		t.Errorf("eigenvectors are not M-orthonormal, difference: %v", diff)
+	}
+
+	if _, _, _, _, err := GeneralizedJacobiMethod(K, [][]float64{{1, 0, 0}, {0, -1, 0}, {0, 0, 1}}, e); err == nil {
+		t.Errorf("expected error for indefinite B")
+	}
+}
+
+func TestBisection(t *testing.T) {
+	// Матрица второй разности: λk = 2 - 2cos(kπ/(n+1))
+	n := 10
+	d := make([]float64, n)
+	off := make([]float64, n-1)
+	for i := range d {
+		d[i] = 2
+	}
+	for i := range off {
+		off[i] = -1
+	}
+
+	exact := func(k int) float64 {
+		return 2 - 2*math.Cos(float64(k+1)*math.Pi/float64(n+1))
+	}
+
+	for idx, value := range BisectionIndices(d, off, 3, 6, e) {
+		if math.Abs(value-exact(3+idx)) > e {
+			t.Errorf("expected: %v, got: %v", exact(3+idx), value)
+		}
+	}
+
+	values := BisectionInterval(d, off, 1, 3, e)
+	if len(values) != 4 {
+		t.Fatalf("expected 4 eigenvalues in [1, 3), got: %v", values)
+	}
+	for idx, value := range values {
+		if math.Abs(value-exact(3+idx)) > e {
+			t.Errorf("expected: %v, got: %v", exact(3+idx), value)
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	A := [][]float64{
+		{4, 1, -2},
+		{1, -3, 0.5},
+		{-2, 0.5, 1},
+	}
+
+	d, err := Decompose(A, e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d.SortByValue()
+	for i := 1; i < len(d.Pairs); i++ {
+		if d.Pairs[i].Value < d.Pairs[i-1].Value {
+			t.Errorf("eigenvalues are not sorted: %v", d.Values())
+		}
+	}
+
+	d.SortByMagnitude()
+	for i := 1; i < len(d.Pairs); i++ {
+		if math.Abs(d.Pairs[i].Value) > math.Abs(d.Pairs[i-1].Value) {
+			t.Errorf("eigenvalues are not sorted by magnitude: %v", d.Values())
+		}
+	}
+
+	if diff := maxDifference(A, d.Reconstruct()); diff > math.Sqrt(e)*10 {
+		t.Errorf("reconstruction difference: %v", diff)
+	}
+	if loss := d.OrthogonalityLoss(); loss > e {
+		t.Errorf("orthogonality loss: %v", loss)
+	}
+	if r := d.MaxResidual(); r > math.Sqrt(e)*10 {
+		t.Errorf("max residual: %v", r)
+	}
+
+	if _, err := Decompose(R[:3], e); err == nil {
+		t.Errorf("expected error for non-symmetric matrix")
+	}
+}
+
+func TestContext(t *testing.T) {
+	A := [][]float64{
+		{4, 1, -2},
+		{1, -3, 0.5},
+		{-2, 0.5, 1},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	steps := 0
+	obs := observer.Func(func(s observer.Step) {
+		steps++
+		if steps == 2 {
+			cancel()
+		}
+	})
+
+	// Отмена после второго вращения: возвращаются текущие приближения
+	Q, values, residuals, counter, err := JacobiMethodContext(ctx, A, e, obs)
+	if err != context.Canceled {
+		t.Errorf("expected: %v, got: %v", context.Canceled, err)
+	}
+	if counter != 2 || len(Q) != len(A) || len(values) != len(A) || len(residuals) != len(A) {
+		t.Errorf("unexpected partial result after %d rotations", counter)
+	}
+
+	u, _, _, counter, err := PowerMethodContext(ctx, A, e, nil)
+	if err != context.Canceled || counter != 0 || len(u) != len(A) {
+		t.Errorf("power method: expected: %v, got: %v after %d iterations", context.Canceled, err, counter)
+	}
+
+	if _, S, _, err := SVDContext(ctx, R, e, SVDThin, nil); err != context.Canceled || len(S) != 3 {
+		t.Errorf("svd: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := DecomposeContext(ctx, A, e); err != context.Canceled {
+		t.Errorf("decompose: expected: %v, got: %v", context.Canceled, err)
+	}
+}
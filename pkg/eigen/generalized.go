@@ -0,0 +1,78 @@
+package eigen
+
+import (
+	"context"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// GeneralizedJacobiMethod Решение обобщенной симметричной задачи на собственные значения A x = λ B x
+// A - симметричная матрица, B - симметричная положительно определенная матрица, eps - точность
+// Задача сводится разложением Холецкого B = L Lᵀ к стандартной задаче C y = λ y, C = L⁻¹ A L⁻ᵀ,
+// которая решается методом Якоби, после чего x = L⁻ᵀ y
+// Возвращает B-ортонормированные собственные векторы (по строкам), собственные значения,
+// невязки ||A x - λ B x|| и количество итераций
+func GeneralizedJacobiMethod(A, B [][]float64, eps float64) ([][]float64, []float64, []float64, int, error) {
+	return GeneralizedJacobiMethodContext(context.Background(), A, B, eps, nil)
+}
+
+// GeneralizedJacobiMethodContext работает как GeneralizedJacobiMethod, но прерывает метод Якоби при отмене ctx
+// или истечении его срока, возвращая текущие приближения и ctx.Err()
+// obs получает итерации метода Якоби для матрицы C (см. JacobiMethodContext, может быть nil)
+func GeneralizedJacobiMethodContext(ctx context.Context, A, B [][]float64, eps float64,
+	obs observer.Observer) ([][]float64, []float64, []float64, int, error) {
+	N := len(A)
+
+	L, err := equations.CholeskyDecomposition(B)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+
+	// Строки X - столбцы L⁻¹ A, т.е. X = (L⁻¹ A)ᵀ = A L⁻ᵀ, тогда C = L⁻¹ X
+	X := make([][]float64, N)
+	for j, col := range tools.TransposeMatrix(A) {
+		X[j] = equations.SolveLowerTriangular(L, col)
+	}
+	C := make([][]float64, N)
+	for j, col := range tools.TransposeMatrix(X) {
+		C[j] = equations.SolveLowerTriangular(L, col)
+	}
+	C = tools.TransposeMatrix(C)
+
+	// Устраняем несимметричность, накопленную ошибками округления
+	for i := 0; i < N; i++ {
+		for j := i + 1; j < N; j++ {
+			C[i][j] = (C[i][j] + C[j][i]) / 2
+			C[j][i] = C[i][j]
+		}
+	}
+
+	Y, eigenvalues, _, counter, err := JacobiMethodContext(ctx, C, eps, obs)
+
+	vectors := make([][]float64, N)
+	errors := make([]float64, N)
+	for i := 0; i < N; i++ {
+		vectors[i] = equations.SolveUpperTransposed(L, Y[i])
+
+		Ax := multiplyMatrixVector(A, vectors[i])
+		Bx := multiplyMatrixVector(B, vectors[i])
+		for j := range Bx {
+			Bx[j] *= eigenvalues[i]
+		}
+		errors[i] = tools.EuclideanNorm(tools.SubtractVectors(Ax, Bx))
+	}
+
+	return vectors, eigenvalues, errors, counter, err
+}
+
+// multiplyMatrixVector умножение матрицы на вектор
+func multiplyMatrixVector(A [][]float64, x []float64) []float64 {
+	result := make([]float64, len(A))
+	for i := range A {
+		result[i] = tools.DotProduct(A[i], x)
+	}
+
+	return result
+}
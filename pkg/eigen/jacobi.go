@@ -1,14 +1,27 @@
 package eigen
 
 import (
-	"github.com/foreverNP/calmet/pkg/tools"
+	"context"
 	"math"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
 )
 
 // JacobiMethod Метод Якоби для нахождения всех собственных значений и собственных векторов
 // A - матрица, для которой ищем собственные значения и вектора, eps - точность
 // Возвращает матрицу собственных векторов, собственные значения, невязку и количество итераций
 func JacobiMethod(A [][]float64, eps float64) ([][]float64, []float64, []float64, int) {
+	Q, eigenvalues, errors, counter, _ := JacobiMethodContext(context.Background(), A, eps, nil)
+
+	return Q, eigenvalues, errors, counter
+}
+
+// JacobiMethodContext работает как JacobiMethod, но проверяет ctx перед каждым вращением
+// obs получает номер итерации, наибольший по модулю диагональный элемент и Off(A) (может быть nil)
+// При отмене ctx или истечении его срока возвращает текущие приближения собственных векторов и значений и ctx.Err()
+func JacobiMethodContext(ctx context.Context, A [][]float64, eps float64,
+	obs observer.Observer) ([][]float64, []float64, []float64, int, error) {
 	// Инициализация единичной матрицы Q и копии матрицы A
 	At := make([][]float64, len(A))
 	Ak := make([][]float64, len(A))
@@ -29,7 +42,12 @@ func JacobiMethod(A [][]float64, eps float64) ([][]float64, []float64, []float64
 	}
 
 	counter := 0
+	var err error
 	for m, e := tools.FindMaxOffDiagonalElement(At); tools.Off(At) > eps; m, e = tools.FindMaxOffDiagonalElement(At) {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
 		a := At[m][m]
 		b := At[e][e]
 
@@ -80,6 +98,14 @@ func JacobiMethod(A [][]float64, eps float64) ([][]float64, []float64, []float64
 		}
 
 		counter++
+
+		if obs != nil {
+			diagonal := make([]float64, len(A))
+			for i := range diagonal {
+				diagonal[i] = At[i][i]
+			}
+			obs.Observe(observer.Step{Estimate: tools.UniformNorm(diagonal), Error: tools.Off(At), N: counter})
+		}
 	}
 
 	// Получаем собственные значения из диагонали матрицы A
@@ -100,5 +126,5 @@ func JacobiMethod(A [][]float64, eps float64) ([][]float64, []float64, []float64
 			tools.TransposeMatrix(tools.MultiplyMatrixByScalar(xi, eigenvalues[i]))[0]))
 	}
 
-	return Q, eigenvalues, errors, counter
+	return Q, eigenvalues, errors, counter, err
 }
@@ -1,11 +1,26 @@
 package eigen
 
-import "github.com/foreverNP/calmet/pkg/tools"
+import (
+	"context"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
 
 // PowerMethod Метод степеней для нахождения максимального собственного значения и соответствующего собственного вектора
 // A - матрица, для которой ищем собственные значения и вектора, e - точность
 // Возвращает собственный вектор, собственное значение, невязку и количество итераций
 func PowerMethod(A [][]float64, e float64) ([]float64, float64, float64, int) {
+	u, h, r, counter, _ := PowerMethodContext(context.Background(), A, e, nil)
+
+	return u, h, r, counter
+}
+
+// PowerMethodContext работает как PowerMethod, но проверяет ctx перед каждой итерацией
+// obs получает номер итерации, приближение собственного значения и невязку (может быть nil)
+// При отмене ctx или истечении его срока возвращает текущие приближения и ctx.Err()
+func PowerMethodContext(ctx context.Context, A [][]float64, e float64,
+	obs observer.Observer) ([]float64, float64, float64, int, error) {
 	N := len(A)
 	y := make([][]float64, N)
 	u := make([][]float64, N)
@@ -19,16 +34,30 @@ func PowerMethod(A [][]float64, e float64) ([]float64, float64, float64, int) {
 	u[0][0] = 1
 
 	h := tools.DotProduct(tools.TransposeMatrix(u)[0], tools.TransposeMatrix(tools.MultiplyMatrices(A, u))[0])
+	r := powerResidual(A, u, h)
+
+	var err error
+	for r > e {
+		if err = ctx.Err(); err != nil {
+			break
+		}
 
-	for tools.EuclideanNorm(tools.SubtractVectors(tools.TransposeMatrix(tools.MultiplyMatrices(A, u))[0],
-		tools.TransposeMatrix(tools.MultiplyMatrixByScalar(u, h))[0])) > e {
 		y = tools.MultiplyMatrices(A, u)
 		u = tools.MultiplyMatrixByScalar(y, 1/tools.EuclideanNorm(tools.TransposeMatrix(y)[0]))
 		h = tools.DotProduct(tools.TransposeMatrix(u)[0], tools.TransposeMatrix(tools.MultiplyMatrices(A, u))[0])
+		r = powerResidual(A, u, h)
 		counter++
+
+		if obs != nil {
+			obs.Observe(observer.Step{Estimate: h, Error: r, N: counter})
+		}
 	}
 
-	return tools.TransposeMatrix(u)[0], h,
-		tools.EuclideanNorm(tools.SubtractVectors(tools.TransposeMatrix(tools.MultiplyMatrices(A, u))[0],
-			tools.TransposeMatrix(tools.MultiplyMatrixByScalar(u, h))[0])), counter
+	return tools.TransposeMatrix(u)[0], h, r, counter, err
+}
+
+// powerResidual вычисляет невязку ||Au - hu|| для вектора-столбца u
+func powerResidual(A, u [][]float64, h float64) float64 {
+	return tools.EuclideanNorm(tools.SubtractVectors(tools.TransposeMatrix(tools.MultiplyMatrices(A, u))[0],
+		tools.TransposeMatrix(tools.MultiplyMatrixByScalar(u, h))[0]))
 }
@@ -0,0 +1,134 @@
+package eigen
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	symmetryTolerance = 1e-12 // допустимая относительная несимметричность входной матрицы
+)
+
+// EigenPair представляет собственную пару матрицы.
+type EigenPair struct {
+	Value    float64   // Собственное значение λ.
+	Vector   []float64 // Нормированный собственный вектор x.
+	Residual float64   // Невязка ||A x - λ x||.
+}
+
+// Decomposition представляет спектральное разложение симметричной матрицы.
+type Decomposition struct {
+	Pairs      []EigenPair // Собственные пары.
+	Iterations int         // Количество итераций метода.
+}
+
+// Decompose Спектральное разложение симметричной матрицы методом Якоби
+// A - симметричная матрица, eps - точность
+// Возвращает ошибку, если матрица не квадратная или не симметричная
+func Decompose(A [][]float64, eps float64) (Decomposition, error) {
+	return DecomposeContext(context.Background(), A, eps)
+}
+
+// DecomposeContext работает как Decompose, но прерывает метод Якоби при отмене ctx или истечении его срока,
+// возвращая разложение по текущим приближениям и ctx.Err()
+func DecomposeContext(ctx context.Context, A [][]float64, eps float64) (Decomposition, error) {
+	for i := range A {
+		if len(A[i]) != len(A) {
+			return Decomposition{}, errors.New("eigen: matrix is not square")
+		}
+	}
+	if !tools.IsSymmetric(A, symmetryTolerance) {
+		return Decomposition{}, errors.New("eigen: matrix is not symmetric")
+	}
+
+	vectors, values, residuals, counter, err := JacobiMethodContext(ctx, A, eps, nil)
+
+	d := Decomposition{
+		Pairs:      make([]EigenPair, len(values)),
+		Iterations: counter,
+	}
+	for i := range values {
+		d.Pairs[i] = EigenPair{Value: values[i], Vector: vectors[i], Residual: residuals[i]}
+	}
+
+	return d, err
+}
+
+// Values возвращает собственные значения в текущем порядке пар.
+func (d Decomposition) Values() []float64 {
+	values := make([]float64, len(d.Pairs))
+	for i, p := range d.Pairs {
+		values[i] = p.Value
+	}
+
+	return values
+}
+
+// Vectors возвращает матрицу, строки которой - собственные векторы в текущем порядке пар.
+func (d Decomposition) Vectors() [][]float64 {
+	vectors := make([][]float64, len(d.Pairs))
+	for i, p := range d.Pairs {
+		vectors[i] = p.Vector
+	}
+
+	return vectors
+}
+
+// SortByValue упорядочивает собственные пары по возрастанию собственных значений.
+func (d *Decomposition) SortByValue() {
+	sort.SliceStable(d.Pairs, func(i, j int) bool { return d.Pairs[i].Value < d.Pairs[j].Value })
+}
+
+// SortByMagnitude упорядочивает собственные пары по убыванию модуля собственных значений.
+func (d *Decomposition) SortByMagnitude() {
+	sort.SliceStable(d.Pairs, func(i, j int) bool { return math.Abs(d.Pairs[i].Value) > math.Abs(d.Pairs[j].Value) })
+}
+
+// Reconstruct восстанавливает матрицу по разложению A = Σ λi xi xiᵀ.
+func (d Decomposition) Reconstruct() [][]float64 {
+	N := len(d.Pairs)
+	A := make([][]float64, N)
+	for i := range A {
+		A[i] = make([]float64, N)
+	}
+
+	for _, p := range d.Pairs {
+		for i := 0; i < N; i++ {
+			for j := 0; j < N; j++ {
+				A[i][j] += p.Value * p.Vector[i] * p.Vector[j]
+			}
+		}
+	}
+
+	return A
+}
+
+// OrthogonalityLoss возвращает меру потери ортогональности собственных векторов max|X Xᵀ - I|.
+func (d Decomposition) OrthogonalityLoss() float64 {
+	loss := 0.0
+	for i, p := range d.Pairs {
+		for j, q := range d.Pairs {
+			value := tools.DotProduct(p.Vector, q.Vector)
+			if i == j {
+				value -= 1
+			}
+			loss = math.Max(loss, math.Abs(value))
+		}
+	}
+
+	return loss
+}
+
+// MaxResidual возвращает наибольшую невязку среди собственных пар.
+func (d Decomposition) MaxResidual() float64 {
+	residual := 0.0
+	for _, p := range d.Pairs {
+		residual = math.Max(residual, p.Residual)
+	}
+
+	return residual
+}
@@ -0,0 +1,39 @@
+package eigen
+
+import "github.com/foreverNP/calmet/pkg/tools"
+
+// Бисекция по последовательности Штурма реализована в пакете tools, чтобы ее могли использовать пакеты нижнего
+// уровня (node) без зависимости от eigen
+
+// GershgorinBounds Границы спектра симметричной трехдиагональной матрицы по кругам Гершгорина
+// d - главная диагональ (n элементов), e - побочная диагональ (n-1 элемент)
+// Возвращает отрезок [lo, hi], содержащий все собственные значения
+func GershgorinBounds(d, e []float64) (float64, float64) {
+	return tools.GershgorinBounds(d, e)
+}
+
+// SturmCount Число перемен знака последовательности Штурма
+// d - главная диагональ, e - побочная диагональ, x - точка
+// Возвращает количество собственных значений симметричной трехдиагональной матрицы, меньших x
+func SturmCount(d, e []float64, x float64) int {
+	return tools.SturmCount(d, e, x)
+}
+
+// BisectionIndices Собственные значения с номерами от i до j включительно (в порядке возрастания, с нуля)
+// симметричной трехдиагональной матрицы методом бисекции
+// d - главная диагональ, e - побочная диагональ, eps - точность
+func BisectionIndices(d, e []float64, i, j int, eps float64) []float64 {
+	if i < 0 || j >= len(d) || i > j {
+		panic("eigen: eigenvalue indices out of range")
+	}
+
+	return tools.BisectionIndices(d, e, i, j, eps)
+}
+
+// BisectionInterval Собственные значения из полуинтервала [a, b) симметричной трехдиагональной матрицы
+// методом бисекции
+// d - главная диагональ, e - побочная диагональ, eps - точность
+// Возвращает собственные значения в порядке возрастания
+func BisectionInterval(d, e []float64, a, b, eps float64) []float64 {
+	return tools.BisectionInterval(d, e, a, b, eps)
+}
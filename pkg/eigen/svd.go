@@ -0,0 +1,286 @@
+package eigen
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// SVDMode определяет, какие части сингулярного разложения нужно вычислить
+type SVDMode int
+
+const (
+	SVDFull   SVDMode = iota // полное разложение: U размера m×m, Vᵀ размера n×n
+	SVDThin                  // экономное разложение: U размера m×k, Vᵀ размера k×n, k = min(m, n)
+	SVDValues                // только сингулярные числа, U и Vᵀ равны nil
+)
+
+const (
+	svdMaxSweeps = 100                   // максимальное количество проходов метода Якоби
+	machEps      = 2.220446049250313e-16 // машинный эпсилон для float64
+)
+
+// SVD Сингулярное разложение A = U Σ Vᵀ односторонним методом Якоби
+// A - прямоугольная матрица m×n, eps - точность (допустимый косинус угла между столбцами), mode - режим вычисления
+// Возвращает матрицу U, сингулярные числа в порядке убывания и матрицу Vᵀ
+func SVD(A [][]float64, eps float64, mode SVDMode) ([][]float64, []float64, [][]float64) {
+	U, S, Vt, _ := SVDContext(context.Background(), A, eps, mode, nil)
+
+	return U, S, Vt
+}
+
+// SVDContext работает как SVD, но проверяет ctx перед каждым проходом метода Якоби
+// obs получает номер прохода, наибольшую норму столбца и наибольший косинус угла между столбцами (может быть nil)
+// При отмене ctx или истечении его срока возвращает разложение по текущим приближениям и ctx.Err()
+func SVDContext(ctx context.Context, A [][]float64, eps float64, mode SVDMode,
+	obs observer.Observer) ([][]float64, []float64, [][]float64, error) {
+	m, n := len(A), len(A[0])
+
+	// Для широкой матрицы раскладываем Aᵀ = U' Σ V'ᵀ, тогда A = V' Σ U'ᵀ
+	if m < n {
+		U, S, Vt, err := SVDContext(ctx, tools.TransposeMatrix(A), eps, mode, obs)
+		if mode == SVDValues {
+			return nil, S, nil, err
+		}
+		return tools.TransposeMatrix(Vt), S, tools.TransposeMatrix(U), err
+	}
+
+	withVectors := mode != SVDValues
+
+	// W - копия A, столбцы которой будут ортогонализованы вращениями, V - накопленные вращения
+	W := make([][]float64, m)
+	for i := range W {
+		W[i] = make([]float64, n)
+		copy(W[i], A[i])
+	}
+	var V [][]float64
+	if withVectors {
+		V = tools.IdentityMatrix(n)
+	}
+
+	var err error
+	for sweep := 0; sweep < svdMaxSweeps; sweep++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		rotated := false
+		maxNorm, maxCos := 0.0, 0.0
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for i := 0; i < m; i++ {
+					alpha += W[i][p] * W[i][p]
+					beta += W[i][q] * W[i][q]
+					gamma += W[i][p] * W[i][q]
+				}
+
+				maxNorm = math.Max(maxNorm, math.Sqrt(math.Max(alpha, beta)))
+				if alpha*beta > 0 {
+					maxCos = math.Max(maxCos, math.Abs(gamma)/math.Sqrt(alpha*beta))
+				}
+
+				if gamma == 0 || math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				// Вращение, зануляющее скалярное произведение столбцов p и q
+				z := (beta - alpha) / (2 * gamma)
+				t := 1.0
+				if z != 0 {
+					t = math.Abs(z) / z / (math.Abs(z) + math.Sqrt(z*z+1))
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := t * c
+
+				rotateColumns(W, p, q, c, s)
+				if withVectors {
+					rotateColumns(V, p, q, c, s)
+				}
+			}
+		}
+
+		if obs != nil {
+			obs.Observe(observer.Step{Estimate: maxNorm, Error: maxCos, N: sweep + 1})
+		}
+
+		if !rotated {
+			break
+		}
+	}
+
+	// Сингулярные числа - нормы столбцов W, упорядочиваем их по убыванию
+	S := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			S[j] += W[i][j] * W[i][j]
+		}
+		S[j] = math.Sqrt(S[j])
+	}
+
+	order := make([]int, n)
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(i, j int) bool { return S[order[i]] > S[order[j]] })
+
+	sorted := make([]float64, n)
+	for j, k := range order {
+		sorted[j] = S[k]
+	}
+
+	if !withVectors {
+		return nil, sorted, nil, err
+	}
+
+	// Столбцы U получаем нормировкой столбцов W. Столбцы, соответствующие нулевым
+	// сингулярным числам, не определены и достраиваются до ортонормированного базиса
+	tol := svdTolerance(m, n, sorted)
+	cols := make([][]float64, 0, m)
+	for _, k := range order {
+		if S[k] <= tol {
+			break
+		}
+		u := make([]float64, m)
+		for i := 0; i < m; i++ {
+			u[i] = W[i][k] / S[k]
+		}
+		cols = append(cols, u)
+	}
+
+	size := n
+	if mode == SVDFull {
+		size = m
+	}
+	cols = completeBasis(cols, m, size)
+
+	U := make([][]float64, m)
+	for i := range U {
+		U[i] = make([]float64, size)
+		for j := 0; j < size; j++ {
+			U[i][j] = cols[j][i]
+		}
+	}
+
+	Vt := make([][]float64, n)
+	for j, k := range order {
+		Vt[j] = make([]float64, n)
+		for i := 0; i < n; i++ {
+			Vt[j][i] = V[i][k]
+		}
+	}
+
+	return U, sorted, Vt, err
+}
+
+// PseudoInverse Псевдообратная матрица Мура-Пенроуза A⁺ = V Σ⁺ Uᵀ
+// A - прямоугольная матрица m×n, eps - точность сингулярного разложения
+// Сингулярные числа, не превосходящие max(m, n)·σmax·ε, считаются нулевыми
+func PseudoInverse(A [][]float64, eps float64) [][]float64 {
+	m, n := len(A), len(A[0])
+	U, S, Vt := SVD(A, eps, SVDThin)
+	tol := svdTolerance(m, n, S)
+
+	P := make([][]float64, n)
+	for i := range P {
+		P[i] = make([]float64, m)
+	}
+	for k := range S {
+		if S[k] <= tol {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			v := Vt[k][i] / S[k]
+			for j := 0; j < m; j++ {
+				P[i][j] += v * U[j][k]
+			}
+		}
+	}
+
+	return P
+}
+
+// Rank Численный ранг матрицы - количество сингулярных чисел, больших max(m, n)·σmax·ε
+// A - прямоугольная матрица, eps - точность сингулярного разложения
+func Rank(A [][]float64, eps float64) int {
+	_, S, _ := SVD(A, eps, SVDValues)
+	tol := svdTolerance(len(A), len(A[0]), S)
+
+	rank := 0
+	for _, s := range S {
+		if s > tol {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+// Cond Число обусловленности матрицы во 2-норме σmax / σmin
+// A - прямоугольная матрица, eps - точность сингулярного разложения
+// Для вырожденной матрицы возвращает +Inf
+func Cond(A [][]float64, eps float64) float64 {
+	_, S, _ := SVD(A, eps, SVDValues)
+	if S[len(S)-1] == 0 {
+		return math.Inf(1)
+	}
+
+	return S[0] / S[len(S)-1]
+}
+
+// svdTolerance порог, ниже которого сингулярные числа считаются нулевыми
+func svdTolerance(m, n int, S []float64) float64 {
+	size := m
+	if n > size {
+		size = n
+	}
+	if len(S) == 0 {
+		return 0
+	}
+
+	return float64(size) * S[0] * machEps
+}
+
+// rotateColumns применяет вращение Якоби к столбцам p и q матрицы M
+func rotateColumns(M [][]float64, p, q int, c, s float64) {
+	for i := range M {
+		mp, mq := M[i][p], M[i][q]
+		M[i][p] = c*mp - s*mq
+		M[i][q] = s*mp + c*mq
+	}
+}
+
+// completeBasis дополняет ортонормированный набор векторов cols длины m до size векторов
+// методом Грама-Шмидта над векторами стандартного базиса
+func completeBasis(cols [][]float64, m, size int) [][]float64 {
+	for k := 0; k < m && len(cols) < size; k++ {
+		v := make([]float64, m)
+		v[k] = 1
+
+		// Двукратная ортогонализация для устойчивости
+		for pass := 0; pass < 2; pass++ {
+			for _, u := range cols {
+				pr := tools.DotProduct(u, v)
+				for i := range v {
+					v[i] -= pr * u[i]
+				}
+			}
+		}
+
+		norm := tools.EuclideanNorm(v)
+		if norm < 0.5 {
+			continue
+		}
+		for i := range v {
+			v[i] /= norm
+		}
+		cols = append(cols, v)
+	}
+
+	return cols
+}
@@ -0,0 +1,82 @@
+package equations
+
+import (
+	"errors"
+	"math"
+)
+
+// CholeskyDecomposition разложение Холецкого симметричной положительно определенной матрицы A = L Lᵀ
+// A - симметричная положительно определенная матрица
+// Возвращает нижнюю треугольную матрицу L или ошибку, если A не является положительно определенной
+func CholeskyDecomposition(A [][]float64) ([][]float64, error) {
+	N := len(A)
+	L := make([][]float64, N)
+	for i := range L {
+		L[i] = make([]float64, N)
+	}
+
+	for j := 0; j < N; j++ {
+		sum := A[j][j]
+		for k := 0; k < j; k++ {
+			sum -= L[j][k] * L[j][k]
+		}
+		if sum <= 0 || math.IsNaN(sum) {
+			return nil, errors.New("equations: matrix is not positive definite")
+		}
+		L[j][j] = math.Sqrt(sum)
+
+		for i := j + 1; i < N; i++ {
+			sum = A[i][j]
+			for k := 0; k < j; k++ {
+				sum -= L[i][k] * L[j][k]
+			}
+			L[i][j] = sum / L[j][j]
+		}
+	}
+
+	return L, nil
+}
+
+// CholeskyMethod решает СЛАУ с симметричной положительно определенной матрицей методом Холецкого
+// A - матрица коэффициентов, B - вектор свободных членов
+func CholeskyMethod(A [][]float64, B []float64) ([]float64, error) {
+	L, err := CholeskyDecomposition(A)
+	if err != nil {
+		return nil, err
+	}
+
+	// L y = B, Lᵀ x = y
+	return SolveUpperTransposed(L, SolveLowerTriangular(L, B)), nil
+}
+
+// SolveLowerTriangular решает СЛАУ L x = B с нижней треугольной матрицей прямой подстановкой
+func SolveLowerTriangular(L [][]float64, B []float64) []float64 {
+	N := len(L)
+	X := make([]float64, N)
+
+	for i := 0; i < N; i++ {
+		sum := B[i]
+		for j := 0; j < i; j++ {
+			sum -= L[i][j] * X[j]
+		}
+		X[i] = sum / L[i][i]
+	}
+
+	return X
+}
+
+// SolveUpperTransposed решает СЛАУ Lᵀ x = B, где L - нижняя треугольная матрица, обратной подстановкой
+func SolveUpperTransposed(L [][]float64, B []float64) []float64 {
+	N := len(L)
+	X := make([]float64, N)
+
+	for i := N - 1; i >= 0; i-- {
+		sum := B[i]
+		for j := i + 1; j < N; j++ {
+			sum -= L[j][i] * X[j]
+		}
+		X[i] = sum / L[i][i]
+	}
+
+	return X
+}
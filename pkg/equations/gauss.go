@@ -1,5 +1,18 @@
 package equations
 
+import (
+	"errors"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// ErrSingular возвращается, если матрица системы вырождена
+var ErrSingular = errors.New("equations: matrix is singular")
+
+// epsilon - машинная точность для float64
+const epsilon = 0x1p-52
+
 // GaussMethod
 // Метод Гаусса для решения СЛАУ
 // A - матрица коэффициентов
@@ -30,3 +43,55 @@ func GaussMethod(A [][]float64, B []float64) []float64 {
 
 	return X
 }
+
+// GaussPivotMethod решает СЛАУ методом Гаусса с выбором главного элемента по столбцу
+// A - матрица коэффициентов, B - вектор свободных членов (не изменяются)
+// Матрица считается численно вырожденной, если главный элемент не превосходит N·ε·||A||
+// (ε - машинная точность): решение такой системы не содержит верных знаков
+// Возвращает вектор решений или ErrSingular
+func GaussPivotMethod(A [][]float64, B []float64) ([]float64, error) {
+	N := len(A)
+	tol := float64(N) * epsilon * tools.MatrixNorm(A)
+
+	// Расширенная матрица [A | B]
+	M := make([][]float64, N)
+	for i := range M {
+		M[i] = make([]float64, N+1)
+		copy(M[i], A[i])
+		M[i][N] = B[i]
+	}
+
+	for i := 0; i < N; i++ {
+		// Выбор главного элемента
+		p := i
+		for j := i + 1; j < N; j++ {
+			if math.Abs(M[j][i]) > math.Abs(M[p][i]) {
+				p = j
+			}
+		}
+		if math.Abs(M[p][i]) <= tol {
+			return nil, ErrSingular
+		}
+		M[i], M[p] = M[p], M[i]
+
+		for j := i + 1; j < N; j++ {
+			l := M[j][i] / M[i][i]
+			M[j][i] = 0
+			for k := i + 1; k <= N; k++ {
+				M[j][k] -= l * M[i][k]
+			}
+		}
+	}
+
+	//Обратный ход
+	X := make([]float64, N)
+	for i := N - 1; i >= 0; i-- {
+		sum := M[i][N]
+		for j := i + 1; j < N; j++ {
+			sum -= M[i][j] * X[j]
+		}
+		X[i] = sum / M[i][i]
+	}
+
+	return X, nil
+}
@@ -0,0 +1,60 @@
+package equations
+
+import (
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// InverseMatrix вычисляет обратную матрицу методом Гаусса-Жордана с выбором главного элемента по столбцу
+// A - квадратная матрица (не изменяется)
+// Как и в GaussPivotMethod, матрица считается численно вырожденной, если главный элемент не превосходит N·ε·||A||
+// Возвращает обратную матрицу или ErrSingular, если матрица вырождена
+func InverseMatrix(A [][]float64) ([][]float64, error) {
+	N := len(A)
+	tol := float64(N) * epsilon * tools.MatrixNorm(A)
+
+	// Расширенная матрица [A | I]
+	M := make([][]float64, N)
+	for i := range M {
+		M[i] = make([]float64, 2*N)
+		copy(M[i], A[i])
+		M[i][N+i] = 1
+	}
+
+	for i := 0; i < N; i++ {
+		// Выбор главного элемента
+		p := i
+		for j := i + 1; j < N; j++ {
+			if math.Abs(M[j][i]) > math.Abs(M[p][i]) {
+				p = j
+			}
+		}
+		if math.Abs(M[p][i]) <= tol {
+			return nil, ErrSingular
+		}
+		M[i], M[p] = M[p], M[i]
+
+		pivot := M[i][i]
+		for k := range M[i] {
+			M[i][k] /= pivot
+		}
+
+		for j := 0; j < N; j++ {
+			if j == i || M[j][i] == 0 {
+				continue
+			}
+			l := M[j][i]
+			for k := i; k < 2*N; k++ {
+				M[j][k] -= l * M[i][k]
+			}
+		}
+	}
+
+	inverse := make([][]float64, N)
+	for i := range inverse {
+		inverse[i] = M[i][N:]
+	}
+
+	return inverse, nil
+}
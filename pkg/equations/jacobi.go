@@ -1,10 +1,25 @@
 package equations
 
-import "github.com/foreverNP/calmet/pkg/tools"
+import (
+	"context"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
 
 // JacobiMethod решает СЛАУ итерационным методом Якоби
 // A - матрица коэффициентов, B - вектор свободных членов, e - точность
 func JacobiMethod(A [][]float64, B []float64, e float64) ([]float64, int) {
+	X, K, _ := JacobiMethodContext(context.Background(), A, B, e, nil)
+
+	return X, K
+}
+
+// JacobiMethodContext работает как JacobiMethod, но проверяет ctx перед каждой итерацией
+// obs получает номер, норму приближения и разность соседних приближений на каждой итерации (может быть nil)
+// При отмене ctx или истечении его срока возвращает текущее приближение и ctx.Err()
+func JacobiMethodContext(ctx context.Context, A [][]float64, B []float64, e float64,
+	obs observer.Observer) ([]float64, int, error) {
 	X1 := make([]float64, len(B))
 	X2 := make([]float64, len(B))
 
@@ -12,6 +27,10 @@ func JacobiMethod(A [][]float64, B []float64, e float64) ([]float64, int) {
 
 	K := 0
 	for ; K < Kmax; K++ {
+		if err := ctx.Err(); err != nil {
+			return X1, K, err
+		}
+
 		for i := 0; i < len(B); i++ {
 			sum := 0.0
 			for j := 0; j < len(B); j++ {
@@ -22,7 +41,12 @@ func JacobiMethod(A [][]float64, B []float64, e float64) ([]float64, int) {
 			X2[i] = (1.0 / A[i][i]) * (B[i] - sum)
 		}
 
-		if tools.MaxAbsoluteDifference(X2, X1) < e {
+		diff := tools.MaxAbsoluteDifference(X2, X1)
+		if obs != nil {
+			obs.Observe(observer.Step{Estimate: tools.UniformNorm(X2), Error: diff, N: K + 1})
+		}
+
+		if diff < e {
 			K++
 			break
 		}
@@ -30,5 +54,5 @@ func JacobiMethod(A [][]float64, B []float64, e float64) ([]float64, int) {
 		copy(X1, X2)
 	}
 
-	return X2, K
+	return X2, K, nil
 }
@@ -1,6 +1,11 @@
 package equations
 
-import "github.com/foreverNP/calmet/pkg/tools"
+import (
+	"context"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
 
 const (
 	Kmax = 1000000 // максимальное количество итераций
@@ -11,6 +16,16 @@ const (
 // A - матрица коэффициентов, B - вектор свободных членов, w - весовой коэффициент, e - точность
 // Возвращает вектор решений и количество итераций
 func RelaxationMethod(A [][]float64, B []float64, w float64, e float64) ([]float64, int) {
+	X, K, _ := RelaxationMethodContext(context.Background(), A, B, w, e, nil)
+
+	return X, K
+}
+
+// RelaxationMethodContext работает как RelaxationMethod, но проверяет ctx перед каждой итерацией
+// obs получает номер, норму приближения и разность соседних приближений на каждой итерации (может быть nil)
+// При отмене ctx или истечении его срока возвращает текущее приближение и ctx.Err()
+func RelaxationMethodContext(ctx context.Context, A [][]float64, B []float64, w float64, e float64,
+	obs observer.Observer) ([]float64, int, error) {
 	if w <= 0 || w >= 2 {
 		panic("Неверный весовой коэффициент!")
 	}
@@ -23,6 +38,10 @@ func RelaxationMethod(A [][]float64, B []float64, w float64, e float64) ([]float
 
 	K := 0
 	for ; K < Kmax; K++ {
+		if err := ctx.Err(); err != nil {
+			return X1, K, err
+		}
+
 		for i := 0; i < len(B); i++ {
 			sum := 0.0
 			for j := 0; j < len(B); j++ {
@@ -33,7 +52,12 @@ func RelaxationMethod(A [][]float64, B []float64, w float64, e float64) ([]float
 			X2[i] = (1.0-w)*X2[i] + (w/A[i][i])*(B[i]-sum)
 		}
 
-		if tools.MaxAbsoluteDifference(X2, X1) < e {
+		diff := tools.MaxAbsoluteDifference(X2, X1)
+		if obs != nil {
+			obs.Observe(observer.Step{Estimate: tools.UniformNorm(X2), Error: diff, N: K + 1})
+		}
+
+		if diff < e {
 			K++
 			break
 		}
@@ -41,5 +65,5 @@ func RelaxationMethod(A [][]float64, B []float64, w float64, e float64) ([]float
 		copy(X1, X2)
 	}
 
-	return X2, K
+	return X2, K, nil
 }
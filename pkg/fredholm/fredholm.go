@@ -0,0 +1,61 @@
+package fredholm
+
+import (
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/integral"
+	"github.com/foreverNP/calmet/pkg/node"
+)
+
+// kernel представляет ядро K(x, t) интегрального уравнения
+type kernel func(x, t float64) float64
+
+// Solution представляет приближенное решение интегрального уравнения Фредгольма второго рода.
+type Solution struct {
+	Nodes  []node.Node // Узлы (X) и веса (Y) квадратурной формулы.
+	Values []float64   // Значения решения в узлах.
+
+	k      kernel
+	f      func(float64) float64
+	lambda float64
+}
+
+// At вычисляет решение в произвольной точке x отрезка по интерполяционной формуле Нистрема
+// u(x) = f(x) + λ Σ w_j K(x, t_j) u_j, которая сохраняет порядок точности квадратурной формулы
+func (s *Solution) At(x float64) float64 {
+	sum := 0.0
+	for j, nd := range s.Nodes {
+		sum += nd.Y * s.k(x, nd.X) * s.Values[j]
+	}
+
+	return s.f(x) + s.lambda*sum
+}
+
+// Solve решает интегральное уравнение Фредгольма второго рода u(x) - λ ∫ K(x,t) u(t) dt = f(x) на [a, b]
+// методом Нистрема: интеграл заменяется квадратурной формулой rule с n узлами t_j и весами w_j, и значения
+// u_j = u(t_j) находятся из СЛАУ u_i - λ Σ w_j K(t_i, t_j) u_j = f(t_i)
+// rule - любая формула из пакета integral (например, integral.ClenshawCurtisNodes) или node.BuildGaussLegendreNodes
+// СЛАУ решается методом Гаусса с выбором главного элемента
+// Возвращает решение, которое можно вычислить в любой точке, или equations.ErrSingular, если система численно
+// вырождена (λ близко к характеристическому числу ядра)
+func Solve(k kernel, f func(float64) float64, lambda, a, b float64, rule integral.QuadratureRule,
+	n int) (*Solution, error) {
+	nodes := rule(a, b, n)
+
+	A := make([][]float64, len(nodes))
+	B := make([]float64, len(nodes))
+	for i := range nodes {
+		A[i] = make([]float64, len(nodes))
+		for j := range nodes {
+			A[i][j] = -lambda * nodes[j].Y * k(nodes[i].X, nodes[j].X)
+		}
+		A[i][i] += 1
+		B[i] = f(nodes[i].X)
+	}
+
+	values, err := equations.GaussPivotMethod(A, B)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Solution{Nodes: nodes, Values: values, k: k, f: f, lambda: lambda}, nil
+}
@@ -0,0 +1,73 @@
+package fredholm
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/integral"
+	"github.com/foreverNP/calmet/pkg/node"
+)
+
+func TestSolve(t *testing.T) {
+	// u(x) - λ ∫[0,1] e^(xt) u(t) dt = f(x) с точным решением u(x) = e^x
+	lambda := 0.5
+	k := func(x, t float64) float64 { return math.Exp(x * t) }
+	f := func(x float64) float64 { return math.Exp(x) - lambda*(math.Exp(x+1)-1)/(x+1) }
+
+	tests := []struct {
+		name string
+		rule integral.QuadratureRule
+		n    int
+		e    float64
+	}{
+		{"Gauss-Legendre", node.BuildGaussLegendreNodes, 10, 1e-12},
+		{"Gauss-Lobatto", node.BuildGaussLobattoNodes, 12, 1e-12},
+		{"Clenshaw-Curtis", integral.ClenshawCurtisNodes, 17, 1e-12},
+		{"Fejer", integral.FejerNodes, 17, 1e-12},
+		{"Simpson", integral.SimpsonNodes, 201, 1e-8},
+		{"trapezoidal", integral.TrapezoidalNodes, 401, 1e-5},
+	}
+
+	for _, tt := range tests {
+		solution, err := Solve(k, f, lambda, 0, 1, tt.rule, tt.n)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+
+		for j, nd := range solution.Nodes {
+			if math.Abs(solution.Values[j]-math.Exp(nd.X)) > tt.e {
+				t.Errorf("%s: node %v: expected: %v, got: %v", tt.name, nd.X, math.Exp(nd.X), solution.Values[j])
+			}
+		}
+
+		// Интерполяция Нистрема между узлами сохраняет точность
+		for _, x := range []float64{0, 0.123, 0.5, 0.777, 1} {
+			if got := solution.At(x); math.Abs(got-math.Exp(x)) > tt.e {
+				t.Errorf("%s: u(%v): expected: %v, got: %v", tt.name, x, math.Exp(x), got)
+			}
+		}
+	}
+
+	// λ = 1 - характеристическое число ядра K = 1 на [0, 1]
+	one := func(x, t float64) float64 { return 1 }
+	if _, err := Solve(one, math.Exp, 1, 0, 1, integral.TrapezoidalNodes, 2); !errors.Is(err, equations.ErrSingular) {
+		t.Errorf("expected error for singular system, got: %v", err)
+	}
+
+	// Сумма весов равна 1 лишь с точностью до округления, поэтому главный элемент мал, но не равен нулю
+	for _, tt := range []struct {
+		name string
+		rule integral.QuadratureRule
+	}{
+		{"Gauss-Legendre", node.BuildGaussLegendreNodes},
+		{"Clenshaw-Curtis", integral.ClenshawCurtisNodes},
+	} {
+		for _, n := range []int{3, 5, 7, 10} {
+			if _, err := Solve(one, math.Exp, 1, 0, 1, tt.rule, n); !errors.Is(err, equations.ErrSingular) {
+				t.Errorf("%s n = %d: expected error for near-singular system, got: %v", tt.name, n, err)
+			}
+		}
+	}
+}
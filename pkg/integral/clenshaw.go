@@ -0,0 +1,244 @@
+package integral
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	clenshawMaxPoints = 1 << 20 // максимальное число отрезков в адаптивной формуле Кленшоу-Кертиса
+)
+
+// ifft вычисляет обратное дискретное преобразование Фурье x(k) = 1/n Σ v(j) e^(2πijk/n)
+// Для n, равного степени двойки, используется итеративный алгоритм БПФ, иначе - прямая формула
+func ifft(v []complex128) []complex128 {
+	n := len(v)
+	x := make([]complex128, n)
+
+	if n&(n-1) != 0 {
+		for k := 0; k < n; k++ {
+			for j := 0; j < n; j++ {
+				x[k] += v[j] * cmplx.Rect(1, 2*math.Pi*float64(j*k%n)/float64(n))
+			}
+			x[k] /= complex(float64(n), 0)
+		}
+		return x
+	}
+
+	// Перестановка с обращением битов
+	copy(x, v)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	// Бабочки Кули-Тьюки
+	for size := 2; size <= n; size <<= 1 {
+		w := cmplx.Rect(1, 2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			wk := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				u, t := x[start+k], wk*x[start+k+size/2]
+				x[start+k] = u + t
+				x[start+k+size/2] = u - t
+				wk *= w
+			}
+		}
+	}
+
+	for k := range x {
+		x[k] /= complex(float64(n), 0)
+	}
+
+	return x
+}
+
+// clenshawCurtisWeights вычисляет веса формулы Кленшоу-Кертиса с узлами cos(kπ/n), k = 0..n, на [-1, 1]
+// с помощью обратного БПФ (алгоритм Вальдфогеля)
+func clenshawCurtisWeights(n int) []float64 {
+	if n == 1 {
+		return []float64{1, 1}
+	}
+
+	l := n / 2 // количество нечетных чисел 1, 3, ..., < n
+	m := n - l // количество оставшихся коэффициентов
+	v := make([]complex128, n)
+	v0 := make([]float64, n+1)
+	for i := 0; i < l; i++ {
+		odd := float64(2*i + 1)
+		v0[i] = 2 / odd / (odd - 2)
+	}
+	v0[l] = 1 / float64(2*l-1)
+
+	// Слагаемое формулы Фейера второго рода и поправка Кленшоу-Кертиса
+	denominator := float64(n*n - 1 + n%2)
+	for k := 0; k < n; k++ {
+		g := -1.0
+		if k == l {
+			g += float64(n)
+		}
+		if k == m {
+			g += float64(n)
+		}
+		v[k] = complex(-v0[k]-v0[n-k]+g/denominator, 0)
+	}
+
+	x := ifft(v)
+	weights := make([]float64, n+1)
+	for k := 0; k < n; k++ {
+		weights[k] = real(x[k])
+	}
+	weights[n] = weights[0]
+
+	return weights
+}
+
+// fejerWeights вычисляет веса формулы Фейера первого рода с узлами cos((k+1/2)π/n), k = 0..n-1, на [-1, 1]
+// с помощью обратного БПФ (алгоритм Вальдфогеля)
+func fejerWeights(n int) []float64 {
+	l := n / 2
+	m := n - l
+	v0 := make([]complex128, n+1)
+	for k := 0; k < m; k++ {
+		fk := float64(k)
+		v0[k] = cmplx.Rect(2/(1-4*fk*fk), math.Pi*fk/float64(n))
+	}
+
+	v := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		v[k] = v0[k] + cmplx.Conj(v0[n-k])
+	}
+
+	x := ifft(v)
+	weights := make([]float64, n)
+	for k := range weights {
+		weights[k] = real(x[k])
+	}
+
+	return weights
+}
+
+// IntegrateClenshawCurtis вычисляет приближенное значение интеграла функции f от a до b
+// с помощью формулы Кленшоу-Кертиса с n+1 узлами (a+b)/2 + (b-a)/2·cos(kπ/n), k = 0..n
+func IntegrateClenshawCurtis(f integrand, a, b float64, n int) float64 {
+	if n < 1 {
+		panic("integral: number of subintervals must be positive")
+	}
+
+	weights := clenshawCurtisWeights(n)
+	sum := 0.0
+	for k, w := range weights {
+		sum += w * f((a+b)/2+(b-a)/2*math.Cos(math.Pi*float64(k)/float64(n)))
+	}
+
+	return (b - a) / 2 * sum
+}
+
+// IntegrateFejer вычисляет приближенное значение интеграла функции f от a до b
+// с помощью формулы Фейера первого рода с n узлами (a+b)/2 + (b-a)/2·cos((k+1/2)π/n), k = 0..n-1
+// Формула не использует значения функции на концах отрезка
+func IntegrateFejer(f integrand, a, b float64, n int) float64 {
+	if n < 1 {
+		panic("integral: number of nodes must be positive")
+	}
+
+	weights := fejerWeights(n)
+	sum := 0.0
+	for k, w := range weights {
+		sum += w * f((a+b)/2+(b-a)/2*math.Cos(math.Pi*(float64(k)+0.5)/float64(n)))
+	}
+
+	return (b - a) / 2 * sum
+}
+
+// IntegrateClenshawCurtisAdaptive вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
+// с помощью формулы Кленшоу-Кертиса, удваивая число отрезков. Узлы формулы вложены, поэтому на каждом шаге
+// функция вычисляется только в новых узлах. Погрешность оценивается разностью соседних приближений
+// Возвращает значение интеграла и оценку погрешности
+func IntegrateClenshawCurtisAdaptive(f integrand, a, b float64, e float64, obs observer.Observer) (float64, float64) {
+	result, _ := IntegrateClenshawCurtisAdaptiveContext(context.Background(), f, a, b, e, obs)
+
+	return result.Value, result.Error
+}
+
+// IntegrateClenshawCurtisAdaptiveContext работает как IntegrateClenshawCurtisAdaptive, но проверяет ctx перед каждым
+// удвоением числа отрезков и возвращает результат с количеством вычислений f и отрезков
+// При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateClenshawCurtisAdaptiveContext(ctx context.Context, f integrand, a, b float64, e float64,
+	obs observer.Observer) (Result, error) {
+	return IntegrateClenshawCurtisAdaptiveWithOptions(ctx, f, a, b, e, Options{Observer: obs})
+}
+
+// IntegrateClenshawCurtisAdaptiveWithOptions работает как IntegrateClenshawCurtisAdaptiveContext с параметрами opts
+// (см. Options). Новые узлы каждого уровня вычисляются одним пакетом в opts.Workers потоках, значения суммируются
+// в порядке узлов. Если следующий уровень превысил бы opts.MaxEvals вычислений f, возвращает ErrEvalBudget
+func IntegrateClenshawCurtisAdaptiveWithOptions(ctx context.Context, f integrand, a, b float64, e float64,
+	opts Options) (Result, error) {
+	n := 2
+	values := evaluatePoints(f, n+1, func(k int) float64 {
+		return (a+b)/2 + (b-a)/2*math.Cos(math.Pi*float64(k)/float64(n))
+	}, opts.workers())
+	evals := n + 1
+
+	prevResult := 0.0
+	result := clenshawCurtisSum(values, a, b, opts.Summation)
+
+	for math.Abs(result-prevResult) > e && n < clenshawMaxPoints {
+		opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: result,
+			Error: math.Abs(result - prevResult), N: n})
+
+		if err := ctx.Err(); err != nil {
+			return Result{Value: result, Error: math.Abs(result - prevResult), Evaluations: evals, Intervals: n}, err
+		}
+		if opts.exceeds(evals, n) {
+			return Result{Value: result, Error: math.Abs(result - prevResult), Evaluations: evals,
+				Intervals: n}, ErrEvalBudget
+		}
+
+		// Старые узлы cos(kπ/n) совпадают с четными узлами cos(2kπ/2n), новые - нечетные узлы 2j+1
+		n *= 2
+		odd := evaluatePoints(f, n/2, func(j int) float64 {
+			return (a+b)/2 + (b-a)/2*math.Cos(math.Pi*float64(2*j+1)/float64(n))
+		}, opts.workers())
+		evals += n / 2
+		refined := make([]float64, n+1)
+		for k := range refined {
+			if k%2 == 0 {
+				refined[k] = values[k/2]
+			} else {
+				refined[k] = odd[k/2]
+			}
+		}
+		values = refined
+
+		prevResult = result
+		result = clenshawCurtisSum(values, a, b, opts.Summation)
+	}
+
+	opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: result,
+		Error: math.Abs(result - prevResult), N: n})
+
+	return Result{Value: result, Error: math.Abs(result - prevResult), Evaluations: evals, Intervals: n}, nil
+}
+
+// clenshawCurtisSum вычисляет формулу Кленшоу-Кертиса по значениям функции в узлах cos(kπ/n), k = 0..n
+// Произведения весов на значения складываются способом summation
+func clenshawCurtisSum(values []float64, a, b float64, summation tools.Summation) float64 {
+	weights := clenshawCurtisWeights(len(values) - 1)
+	terms := make([]float64, len(weights))
+	for k, w := range weights {
+		terms[k] = w * values[k]
+	}
+
+	return (b - a) / 2 * tools.Sum(terms, summation)
+}
@@ -0,0 +1,364 @@
+package integral
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/foreverNP/calmet/pkg/node"
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	nestedLimit         = 100     // максимальное количество подотрезков по каждой переменной во вложенном интегрировании
+	genzMalikDefaultMax = 1000000 // ограничение на число вычислений функции в методе Генца-Малика по умолчанию
+)
+
+// integrand2D представляет функцию двух переменных, которую необходимо интегрировать
+type integrand2D func(x, y float64) float64
+
+// integrandND представляет функцию нескольких переменных, которую необходимо интегрировать
+// Слайс аргументов переиспользуется между вызовами, поэтому функция не должна его сохранять
+type integrandND func(x []float64) float64
+
+// IntegrateGaussLegendre2D вычисляет приближенное значение интеграла функции f по прямоугольнику [ax, bx] × [ay, by]
+// с помощью тензорного произведения формул Гаусса-Лежандра с n узлами по каждой переменной
+func IntegrateGaussLegendre2D(f integrand2D, ax, bx, ay, by float64, n int) float64 {
+	nodesX := node.BuildGaussLegendreNodes(ax, bx, n)
+	nodesY := node.BuildGaussLegendreNodes(ay, by, n)
+
+	sum := 0.0
+	for _, nx := range nodesX {
+		for _, ny := range nodesY {
+			sum += nx.Y * ny.Y * f(nx.X, ny.X)
+		}
+	}
+
+	return sum
+}
+
+// IntegrateGaussLegendreND вычисляет приближенное значение интеграла функции f по параллелепипеду
+// [a[0], b[0]] × ... × [a[d-1], b[d-1]] с помощью тензорного произведения формул Гаусса-Лежандра
+// с n узлами по каждой переменной (n^d вычислений функции)
+func IntegrateGaussLegendreND(f integrandND, a, b []float64, n int) float64 {
+	d := len(a)
+	if d == 0 || len(b) != d {
+		panic("integral: bounds must have the same positive length")
+	}
+
+	nodes := make([][]node.Node, d)
+	for k := range nodes {
+		nodes[k] = node.BuildGaussLegendreNodes(a[k], b[k], n)
+	}
+
+	// Перебираем все мультииндексы (i0, ..., id-1) как разряды числа в системе счисления с основанием n
+	index := make([]int, d)
+	x := make([]float64, d)
+	sum := 0.0
+	for {
+		w := 1.0
+		for k := 0; k < d; k++ {
+			x[k] = nodes[k][index[k]].X
+			w *= nodes[k][index[k]].Y
+		}
+		sum += w * f(x)
+
+		k := 0
+		for ; k < d; k++ {
+			index[k]++
+			if index[k] < n {
+				break
+			}
+			index[k] = 0
+		}
+		if k == d {
+			break
+		}
+	}
+
+	return sum
+}
+
+// IntegrateNested вычисляет приближенное значение интеграла функции f по параллелепипеду [a, b] с заданной точностью e
+// как повторный интеграл, вычисляя каждый одномерный интеграл адаптивным методом Гаусса-Кронрода GK21
+// Допустимая погрешность делится между внешним интегралом и внутренними интегралами
+// Возвращает результат с оценкой погрешности внешнего интеграла и общим количеством вычислений f
+func IntegrateNested(f integrandND, a, b []float64, e float64) (Result, error) {
+	return IntegrateNestedContext(context.Background(), f, a, b, e)
+}
+
+// IntegrateNestedContext работает как IntegrateNested, но прерывает вычисления при отмене ctx
+// или истечении его срока, возвращая лучшее полученное приближение и ctx.Err()
+func IntegrateNestedContext(ctx context.Context, f integrandND, a, b []float64, e float64) (Result, error) {
+	return IntegrateNestedWithOptions(ctx, f, a, b, e, Options{})
+}
+
+// IntegrateNestedWithOptions работает как IntegrateNestedContext с параметрами opts (см. Options)
+// В opts.Workers потоках вычисляются значения внешнего интеграла (внутренние интегралы, а при d = 1 - значения f)
+// в узлах двух половин делимого подотрезка; внутренние интегралы одного узла вычисляются последовательно
+// opts.Summation используется всеми одномерными интегралами, opts.Observer получает шаги внешнего интеграла
+// (см. IntegrateGaussKronrodWithOptions; количество вычислений f известно только в итоговом результате). Бюджет opts.MaxEvals относится к общему числу
+// вычислений f и проверяется перед каждым делением подотрезка, поэтому может быть немного превышен;
+// при его исчерпании возвращается лучшее приближение и ErrEvalBudget
+func IntegrateNestedWithOptions(ctx context.Context, f integrandND, a, b []float64, e float64,
+	opts Options) (Result, error) {
+	if len(a) == 0 || len(b) != len(a) {
+		panic("integral: bounds must have the same positive length")
+	}
+
+	// Исчерпание бюджета отменяет контекст, и все одномерные интегралы завершаются с лучшими приближениями
+	budgetCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	if opts.MaxEvals > 0 {
+		var calls atomic.Int64
+		g := f
+		f = func(x []float64) float64 {
+			if calls.Add(1) == int64(opts.MaxEvals) {
+				cancel(ErrEvalBudget)
+			}
+			return g(x)
+		}
+	}
+
+	x := make([]float64, len(a))
+	result, err := nestedLevel(budgetCtx, f, a, b, e, x, 0, Options{Workers: opts.Workers, Summation: opts.Summation,
+		Observer: opts.Observer})
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(budgetCtx), ErrEvalBudget) {
+		err = ErrEvalBudget
+	}
+
+	return result, err
+}
+
+// nestedLevel вычисляет интеграл по переменным с номерами k, k+1, ... при фиксированных x[0..k-1]
+// При opts.Workers != 0 и 1 значения вычисляются одновременно, поэтому каждое вычисление использует свою копию x
+func nestedLevel(ctx context.Context, f integrandND, a, b []float64, e float64, x []float64, k int,
+	opts Options) (Result, error) {
+	point := func(t float64) []float64 {
+		y := x
+		if opts.workers() != 1 {
+			y = append([]float64(nil), x...)
+		}
+		y[k] = t
+		return y
+	}
+
+	if k == len(a)-1 {
+		return IntegrateGaussKronrodWithOptions(ctx, func(t float64) float64 {
+			return f(point(t))
+		}, a[k], b[k], e, GK21, nestedLimit, opts)
+	}
+
+	// Погрешность внутренних интегралов умножается на длину отрезка по x[k]
+	innerE := e / 2 / math.Max(math.Abs(b[k]-a[k]), 1)
+	var mu sync.Mutex
+	evals := 0
+	var innerErr error
+
+	// Внутренние интегралы вычисляются последовательно: параллельны только вычисления внешнего уровня
+	result, err := IntegrateGaussKronrodWithOptions(ctx, func(t float64) float64 {
+		inner, err := nestedLevel(ctx, f, a, b, innerE, point(t), k+1, Options{Summation: opts.Summation})
+
+		mu.Lock()
+		defer mu.Unlock()
+		evals += inner.Evaluations
+		if err != nil && innerErr == nil {
+			innerErr = err
+		}
+		return inner.Value
+	}, a[k], b[k], e/2, GK21, nestedLimit, opts)
+
+	result.Evaluations = evals
+	if err == nil {
+		err = innerErr
+	}
+
+	return result, err
+}
+
+// Параметры правила Генца-Малика степени 7 со вложенным правилом степени 5
+var (
+	genzMalikLambda2 = math.Sqrt(9.0 / 70)
+	genzMalikLambda4 = math.Sqrt(9.0 / 10)
+	genzMalikLambda5 = math.Sqrt(9.0 / 19)
+)
+
+// genzMalikBox параллелепипед разбиения с центром c и полуширинами h
+type genzMalikBox struct {
+	c, h  []float64
+	value float64
+	err   float64
+	split int // переменная, по которой параллелепипед следует делить
+}
+
+// genzMalikRule применяет правило Генца-Малика к параллелепипеду box, заполняя значение, оценку погрешности
+// и направление деления (по наибольшей четвертой разности). Возвращает количество вычислений f
+func genzMalikRule(f integrandND, box *genzMalikBox) int {
+	d := len(box.c)
+	fd := float64(d)
+
+	x := make([]float64, d)
+	copy(x, box.c)
+
+	volume := 1.0
+	for _, h := range box.h {
+		volume *= 2 * h
+	}
+
+	f0 := f(x)
+	evals := 1
+
+	sum2, sum3, sum4, sum5 := 0.0, 0.0, 0.0, 0.0
+	maxDiff := -1.0
+	for i := 0; i < d; i++ {
+		x[i] = box.c[i] - genzMalikLambda2*box.h[i]
+		f2 := f(x)
+		x[i] = box.c[i] + genzMalikLambda2*box.h[i]
+		f2 += f(x)
+		x[i] = box.c[i] - genzMalikLambda4*box.h[i]
+		f3 := f(x)
+		x[i] = box.c[i] + genzMalikLambda4*box.h[i]
+		f3 += f(x)
+		x[i] = box.c[i]
+		evals += 4
+
+		sum2 += f2
+		sum3 += f3
+
+		// Четвертая разность вдоль i-й переменной
+		diff := math.Abs(f2 - 2*f0 - (genzMalikLambda2*genzMalikLambda2)/(genzMalikLambda4*genzMalikLambda4)*(f3-2*f0))
+		if diff > maxDiff {
+			maxDiff = diff
+			box.split = i
+		}
+	}
+
+	// Точки (±λ4, ±λ4) в плоскостях пар переменных
+	for i := 0; i < d; i++ {
+		for j := i + 1; j < d; j++ {
+			for _, si := range [2]float64{-1, 1} {
+				for _, sj := range [2]float64{-1, 1} {
+					x[i] = box.c[i] + si*genzMalikLambda4*box.h[i]
+					x[j] = box.c[j] + sj*genzMalikLambda4*box.h[j]
+					sum4 += f(x)
+					evals++
+				}
+			}
+			x[i], x[j] = box.c[i], box.c[j]
+		}
+	}
+
+	// Вершины параллелепипеда (±λ5, ..., ±λ5)
+	for mask := 0; mask < 1<<uint(d); mask++ {
+		for i := 0; i < d; i++ {
+			if mask&(1<<uint(i)) != 0 {
+				x[i] = box.c[i] + genzMalikLambda5*box.h[i]
+			} else {
+				x[i] = box.c[i] - genzMalikLambda5*box.h[i]
+			}
+		}
+		sum5 += f(x)
+		evals++
+	}
+
+	r7 := (12824-9120*fd+400*fd*fd)/19683*f0 + 980.0/6561*sum2 + (1820-400*fd)/19683*sum3 +
+		200.0/19683*sum4 + 6859.0/19683/math.Ldexp(1, d)*sum5
+	r5 := (729-950*fd+50*fd*fd)/729*f0 + 245.0/486*sum2 + (265-100*fd)/1458*sum3 + 25.0/729*sum4
+
+	box.value = volume * r7
+	box.err = math.Abs(volume * (r7 - r5))
+
+	return evals
+}
+
+// IntegrateGenzMalik вычисляет приближенное значение интеграла функции f по параллелепипеду [a, b] размерности d >= 2
+// с заданной точностью e адаптивным методом Генца-Малика: правило степени 7 со вложенным правилом степени 5
+// дает оценку погрешности, и на каждом шаге параллелепипед с наибольшей погрешностью делится пополам
+// по переменной с наибольшей четвертой разностью. maxEvals - максимальное количество вычислений f
+// (0 - ограничение по умолчанию 10^6)
+// Возвращает результат и ErrEvalBudget, если точность не достигнута за отведенное число вычислений
+func IntegrateGenzMalik(f integrandND, a, b []float64, e float64, maxEvals int) (Result, error) {
+	return IntegrateGenzMalikContext(context.Background(), f, a, b, e, maxEvals)
+}
+
+// IntegrateGenzMalikContext работает как IntegrateGenzMalik, но проверяет ctx перед каждым делением параллелепипеда
+// При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateGenzMalikContext(ctx context.Context, f integrandND, a, b []float64, e float64, maxEvals int) (Result, error) {
+	return IntegrateGenzMalikWithOptions(ctx, f, a, b, e, Options{MaxEvals: maxEvals})
+}
+
+// IntegrateGenzMalikWithOptions работает как IntegrateGenzMalikContext с параметрами opts (см. Options)
+// Правило применяется к двум половинам делимого параллелепипеда в opts.Workers потоках, значения и оценки
+// погрешности параллелепипедов складываются способом opts.Summation. После каждого деления opts.Observer получает
+// ширину половин по выбранной переменной, сумму, оценку погрешности и количество параллелепипедов
+// opts.MaxEvals = 0 означает ограничение по умолчанию 10^6
+func IntegrateGenzMalikWithOptions(ctx context.Context, f integrandND, a, b []float64, e float64,
+	opts Options) (Result, error) {
+	d := len(a)
+	if d < 2 || len(b) != d {
+		panic("integral: Genz-Malik cubature requires bounds of the same length d >= 2")
+	}
+
+	root := genzMalikBox{c: make([]float64, d), h: make([]float64, d)}
+	for k := 0; k < d; k++ {
+		root.c[k] = (a[k] + b[k]) / 2
+		root.h[k] = (b[k] - a[k]) / 2
+	}
+
+	maxEvals := opts.MaxEvals
+	if maxEvals <= 0 {
+		maxEvals = genzMalikDefaultMax
+	}
+
+	evalsPerBox := genzMalikRule(f, &root)
+	boxes := []genzMalikBox{root}
+	result := Result{Value: root.value, Error: root.err, Evaluations: evalsPerBox, Intervals: 1}
+	opts.observe(observer.Step{H: 2 * root.h[root.split], Estimate: result.Value, Error: result.Error, N: 1})
+
+	for result.Error > e {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if result.Evaluations+2*evalsPerBox > maxEvals {
+			return result, ErrEvalBudget
+		}
+
+		worst := 0
+		for i := range boxes {
+			if boxes[i].err > boxes[worst].err {
+				worst = i
+			}
+		}
+
+		// Делим параллелепипед пополам по выбранной переменной
+		box := boxes[worst]
+		k := box.split
+		left := genzMalikBox{c: append([]float64(nil), box.c...), h: append([]float64(nil), box.h...)}
+		right := genzMalikBox{c: append([]float64(nil), box.c...), h: append([]float64(nil), box.h...)}
+		left.h[k] /= 2
+		right.h[k] /= 2
+		left.c[k] -= left.h[k]
+		right.c[k] += right.h[k]
+
+		halves := []*genzMalikBox{&left, &right}
+		parallelFor(len(halves), opts.workers(), func(i int) {
+			genzMalikRule(f, halves[i])
+		})
+		result.Evaluations += 2 * evalsPerBox
+		boxes[worst] = left
+		boxes = append(boxes, right)
+
+		values, errs := make([]float64, len(boxes)), make([]float64, len(boxes))
+		for i := range boxes {
+			values[i], errs[i] = boxes[i].value, boxes[i].err
+		}
+		result.Value, result.Error = tools.Sum(values, opts.Summation), tools.Sum(errs, opts.Summation)
+		result.Intervals = len(boxes)
+		opts.observe(observer.Step{H: 2 * left.h[k], Estimate: result.Value, Error: result.Error, N: result.Intervals})
+	}
+
+	return result, nil
+}
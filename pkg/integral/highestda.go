@@ -1,6 +1,13 @@
 package integral
 
-import "github.com/foreverNP/calmet/pkg/node"
+import (
+	"context"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/node"
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
 
 // IntegrateGaussLegendre вычисляет приближенное значение интеграла функции f от a до b
 // с помощью квадратурной формулы Гаусса-Лежандра с n узлами
@@ -13,3 +20,107 @@ func IntegrateGaussLegendre(f integrand, a, b float64, n int) float64 {
 
 	return sum
 }
+
+// rungeErrorGaussLegendre вычисляет оценку погрешности методом Рунге для составной формулы
+// Гаусса-Лежандра с n узлами (порядок точности 2n)
+func rungeErrorGaussLegendre(q2, q float64, n int) float64 {
+	return (q2 - q) / (math.Ldexp(1, 2*n) - 1)
+}
+
+// gaussLegendreError оценивает погрешность приближения q2 по разности diff = |q2 - q| с предыдущим приближением
+// и предыдущей разностью prevDiff (+Inf, если ее нет). Оценка Рунге с делителем 2^(2n) - 1 верна только
+// в асимптотическом режиме, поэтому она используется, лишь когда разности убывают, и не может быть меньше оценки
+// по наблюдаемому отношению разностей r = diff / prevDiff: diff·r / (1 - r). Иначе оценкой служит сама разность
+func gaussLegendreError(q2, q, prevDiff float64, n int) float64 {
+	diff := math.Abs(q2 - q)
+	if diff == 0 {
+		return 0
+	}
+
+	ratio := diff / prevDiff
+	if ratio >= 1 || math.IsNaN(ratio) || math.IsInf(prevDiff, 1) {
+		return diff
+	}
+
+	return math.Max(math.Abs(rungeErrorGaussLegendre(q2, q, n)), diff*ratio/(1-ratio))
+}
+
+// gaussLegendreRule вычисляет составную формулу Гаусса-Лежандра с n узлами на m равных подотрезках
+// Значения функции вычисляются в workers потоках (см. evaluatePoints) и складываются способом summation
+// nodes - узлы и веса на [-1, 1]
+func gaussLegendreRule(f integrand, a, b float64, nodes []node.Node, m, workers int,
+	summation tools.Summation) float64 {
+	h := (b - a) / float64(m)
+	values := evaluatePoints(f, m*len(nodes), func(i int) float64 {
+		center := a + (float64(i/len(nodes))+0.5)*h
+		return center + h/2*nodes[i%len(nodes)].X
+	}, workers)
+
+	for i := range values {
+		values[i] *= nodes[i%len(nodes)].Y
+	}
+
+	return h / 2 * tools.Sum(values, summation)
+}
+
+// IntegrateGaussLegendreComposite вычисляет приближенное значение интеграла функции f от a до b
+// с помощью составной квадратурной формулы Гаусса-Лежандра с n узлами на каждом из m равных подотрезков
+func IntegrateGaussLegendreComposite(f integrand, a, b float64, n, m int) float64 {
+	return gaussLegendreRule(f, a, b, node.BuildGaussLegendreNodes(-1, 1, n), m, 1, tools.NeumaierSum)
+}
+
+// IntegrateGaussLegendreAdaptive вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
+// с помощью составной кф Гаусса-Лежандра с n узлами, удваивая число подотрезков, и метода Рунге для оценки погрешности
+// Возвращает значение интеграла и оценку погрешности
+func IntegrateGaussLegendreAdaptive(f integrand, a, b float64, n int, e float64, obs observer.Observer) (float64, float64) {
+	result, _ := IntegrateGaussLegendreAdaptiveContext(context.Background(), f, a, b, n, e, obs)
+
+	return result.Value, result.Error
+}
+
+// IntegrateGaussLegendreAdaptiveContext работает как IntegrateGaussLegendreAdaptive, но проверяет ctx перед каждым
+// удвоением числа подотрезков и возвращает результат с количеством вычислений f и подотрезков
+// При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateGaussLegendreAdaptiveContext(ctx context.Context, f integrand, a, b float64, n int, e float64,
+	obs observer.Observer) (Result, error) {
+	return IntegrateGaussLegendreAdaptiveWithOptions(ctx, f, a, b, n, e, Options{Observer: obs})
+}
+
+// IntegrateGaussLegendreAdaptiveWithOptions работает как IntegrateGaussLegendreAdaptiveContext с параметрами opts
+// (см. Options). Все узлы каждого уровня вычисляются одним пакетом в opts.Workers потоках и суммируются в порядке
+// узлов. Если следующий уровень превысил бы opts.MaxEvals вычислений f, возвращает ErrEvalBudget
+func IntegrateGaussLegendreAdaptiveWithOptions(ctx context.Context, f integrand, a, b float64, n int, e float64,
+	opts Options) (Result, error) {
+	nodes := node.BuildGaussLegendreNodes(-1, 1, n)
+
+	// Погрешность оценивается только по двум настоящим приближениям, поэтому число подотрезков удваивается хотя бы раз:
+	// при больших n знаменатель 2^(2n) - 1 сделал бы оценку по одному приближению ложно малой
+	m := 2
+	prevResult := gaussLegendreRule(f, a, b, nodes, 1, opts.workers(), opts.Summation)
+	result := gaussLegendreRule(f, a, b, nodes, m, opts.workers(), opts.Summation)
+	estimate := gaussLegendreError(result, prevResult, math.Inf(1), n)
+	evals := 3 * n
+
+	// Вычисляем приближенное значение интеграла с заданной точностью e с помощью метода Рунге
+	for estimate > e {
+		opts.observe(observer.Step{H: (b - a) / float64(m), Estimate: result, Error: estimate, N: m})
+
+		if err := ctx.Err(); err != nil {
+			return Result{Value: result, Error: estimate, Evaluations: evals, Intervals: m}, err
+		}
+		if opts.exceeds(evals, 2*m*n) {
+			return Result{Value: result, Error: estimate, Evaluations: evals, Intervals: m}, ErrEvalBudget
+		}
+
+		prevDiff := math.Abs(result - prevResult)
+		prevResult = result
+		m *= 2
+		result = gaussLegendreRule(f, a, b, nodes, m, opts.workers(), opts.Summation)
+		evals += m * n
+		estimate = gaussLegendreError(result, prevResult, prevDiff, n)
+	}
+
+	opts.observe(observer.Step{H: (b - a) / float64(m), Estimate: result, Error: estimate, N: m})
+
+	return Result{Value: result, Error: estimate, Evaluations: evals, Intervals: m}, nil
+}
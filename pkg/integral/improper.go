@@ -0,0 +1,121 @@
+package integral
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+const (
+	decayDecades = 6   // число декад, на которых проверяется убывание функции на бесконечности
+	decayChecked = 3   // число последних декад, на каждой из которых огибающая должна убывать
+	decaySamples = 20  // число точек на декаду, по которым вычисляется огибающая
+	decayFactor  = 0.9 // огибающая на следующей декаде должна быть не больше decayFactor от предыдущей
+)
+
+// ErrNotDecaying возвращается, если подынтегральная функция не убывает на бесконечности
+var ErrNotDecaying = errors.New("integral: integrand does not decay at infinity")
+
+// isDecaying эвристически проверяет убывание |x f(x)| на бесконечности. Для каждой декады
+// [x0 + sign·R·10^k, x0 + sign·R·10^(k+1)], k = 0..decayDecades-1, вычисляется огибающая - максимум |x f(x)|
+// в decaySamples точках, равномерно расположенных в логарифмической шкале. Функция считается убывающей,
+// если на каждой из последних decayChecked декад огибающая уменьшается хотя бы в 1/decayFactor раз (или равна нулю)
+// Огибающая не дает принять осциллирующий хвост (sin(x)/x, cos(x)) из-за случайного расположения точек,
+// но проверка по конечному числу точек остается эвристикой и может ошибиться для специально подобранных функций
+func isDecaying(f integrand, x0, sign float64) bool {
+	R := math.Max(1, math.Abs(x0))
+	envelope := make([]float64, decayDecades)
+
+	for k := range envelope {
+		for j := 0; j < decaySamples; j++ {
+			x := x0 + sign*R*math.Pow(10, float64(k)+float64(j)/decaySamples)
+			v := math.Abs(x * f(x))
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return false
+			}
+			envelope[k] = math.Max(envelope[k], v)
+		}
+	}
+
+	for k := decayDecades - decayChecked; k < decayDecades; k++ {
+		if envelope[k] != 0 && envelope[k] > decayFactor*envelope[k-1] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IntegrateImproper вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e,
+// где границы могут быть бесконечными (math.Inf). Бесконечный промежуток отображается на конечный заменой переменной:
+// [a, ∞): x = a + t/(1-t); (-∞, b]: x = b - (1-t)/t; (-∞, ∞): x = t/(1-t²),
+// после чего интеграл вычисляется адаптивным методом Гаусса-Кронрода GK15, не использующим значения на концах
+// limit - максимальное количество подотрезков
+// Возвращает ErrNotDecaying, если f не убывает на бесконечности, и ErrIntervalLimit, если точность не достигнута
+func IntegrateImproper(f integrand, a, b float64, e float64, limit int) (Result, error) {
+	return IntegrateImproperContext(context.Background(), f, a, b, e, limit)
+}
+
+// IntegrateImproperContext работает как IntegrateImproper, но прерывает вычисления при отмене ctx
+// или истечении его срока, возвращая лучшее полученное приближение и ctx.Err()
+func IntegrateImproperContext(ctx context.Context, f integrand, a, b float64, e float64, limit int) (Result, error) {
+	return IntegrateImproperWithOptions(ctx, f, a, b, e, limit, Options{})
+}
+
+// IntegrateImproperWithOptions работает как IntegrateImproperContext с параметрами opts (см. Options), которые
+// передаются методу Гаусса-Кронрода на отображенном отрезке (см. IntegrateGaussKronrodWithOptions)
+// Значения, вычисленные при проверке убывания, в бюджет opts.MaxEvals не входят
+func IntegrateImproperWithOptions(ctx context.Context, f integrand, a, b float64, e float64, limit int,
+	opts Options) (Result, error) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		panic("integral: NaN integration bound")
+	}
+
+	// Меняем границы местами, чтобы a < b
+	if a > b {
+		result, err := IntegrateImproperWithOptions(ctx, f, b, a, e, limit, opts)
+		result.Value = -result.Value
+		return result, err
+	}
+	if a == b {
+		return Result{}, nil
+	}
+
+	lowerInf, upperInf := math.IsInf(a, -1), math.IsInf(b, 1)
+	if lowerInf && upperInf {
+		if !isDecaying(f, 0, 1) || !isDecaying(f, 0, -1) {
+			return Result{}, ErrNotDecaying
+		}
+
+		g := func(t float64) float64 {
+			d := 1 - t*t
+			return f(t/d) * (1 + t*t) / (d * d)
+		}
+		return IntegrateGaussKronrodWithOptions(ctx, g, -1, 1, e, GK15, limit, opts)
+	}
+
+	if upperInf {
+		if !isDecaying(f, a, 1) {
+			return Result{}, ErrNotDecaying
+		}
+
+		g := func(t float64) float64 {
+			d := 1 - t
+			return f(a+t/d) / (d * d)
+		}
+		return IntegrateGaussKronrodWithOptions(ctx, g, 0, 1, e, GK15, limit, opts)
+	}
+
+	if lowerInf {
+		if !isDecaying(f, b, -1) {
+			return Result{}, ErrNotDecaying
+		}
+
+		g := func(t float64) float64 {
+			return f(b-(1-t)/t) / (t * t)
+		}
+		return IntegrateGaussKronrodWithOptions(ctx, g, 0, 1, e, GK15, limit, opts)
+	}
+
+	return IntegrateGaussKronrodWithOptions(ctx, f, a, b, e, GK21, limit, opts)
+}
@@ -1,8 +1,18 @@
 package integral
 
 import (
+	"context"
+	"errors"
 	"math"
+	"math/cmplx"
+	"math/rand"
+	"sync"
 	"testing"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/node"
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
 )
 
 const (
@@ -28,11 +38,23 @@ func TestIntegrateGaussLegendre(t *testing.T) {
 }
 
 func TestIntegrateSimpson(t *testing.T) {
-	resultSimpson := IntegrateSimpson(f, a, b, e, nil)
+	var recorder observer.Recorder
+	resultSimpson := IntegrateSimpson(f, a, b, e, &recorder)
 
 	if math.Abs(I-resultSimpson) > e {
 		t.Errorf("expected: %v, got: %v", I, resultSimpson)
 	}
+
+	// Число отрезков удваивается на каждой итерации, последняя итерация содержит итоговое значение
+	steps := recorder.Steps
+	if len(steps) < 2 || steps[len(steps)-1].Estimate != resultSimpson || steps[len(steps)-1].Error > e {
+		t.Fatalf("unexpected steps: %v", steps)
+	}
+	for i := 1; i < len(steps); i++ {
+		if steps[i].N != 2*steps[i-1].N || steps[i].H != steps[i-1].H/2 {
+			t.Errorf("unexpected step %d: %v after %v", i, steps[i], steps[i-1])
+		}
+	}
 }
 
 func TestIntegrateTrapezoidal(t *testing.T) {
@@ -42,3 +64,985 @@ func TestIntegrateTrapezoidal(t *testing.T) {
 		t.Errorf("expected: %v, got: %v", I, resultTrapezoid)
 	}
 }
+
+func TestIntegrateGaussLegendreHighOrder(t *testing.T) {
+	// Формула с n узлами точна для многочленов степени 2n-1
+	var wg sync.WaitGroup
+	for _, n := range []int{1, 2, 7, 16, 40, 100} {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+
+			p := func(x float64) float64 {
+				return float64(2*n) * math.Pow(x, float64(2*n-1))
+			}
+
+			if result := IntegrateGaussLegendre(p, 0, 1, n); math.Abs(result-1) > e {
+				t.Errorf("n = %d: expected: %v, got: %v", n, 1, result)
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if result := IntegrateGaussLegendre(f, a, b, 12); math.Abs(I-result) > e {
+		t.Errorf("expected: %v, got: %v", I, result)
+	}
+}
+
+func TestIntegrateGaussLegendreComposite(t *testing.T) {
+	resultComposite := IntegrateGaussLegendreComposite(f, a, b, 3, 16)
+
+	if math.Abs(I-resultComposite) > e {
+		t.Errorf("expected: %v, got: %v", I, resultComposite)
+	}
+}
+
+func TestIntegrateGaussLegendreAdaptive(t *testing.T) {
+	resultAdaptive, estimate := IntegrateGaussLegendreAdaptive(f, a, b, 4, e, nil)
+
+	if math.Abs(I-resultAdaptive) > e {
+		t.Errorf("expected: %v, got: %v", I, resultAdaptive)
+	}
+	if estimate > e {
+		t.Errorf("error estimate %v exceeds tolerance", estimate)
+	}
+
+	// При большом n одно приближение с m = 1 не должно приниматься за сошедшееся
+	exact := math.Sin(50) / 50
+	oscillating := func(x float64) float64 { return math.Cos(50 * x) }
+	for _, n := range []int{8, 10, 16} {
+		result, estimate := IntegrateGaussLegendreAdaptive(oscillating, 0, 1, n, 1e-6, nil)
+		if math.Abs(exact-result) > 1e-6 || estimate > 1e-6 {
+			t.Errorf("n = %d: expected: %v, got: %v (estimate %v)", n, exact, result, estimate)
+		}
+	}
+}
+
+func TestIntegrateGaussKronrod(t *testing.T) {
+	for _, rule := range []KronrodRule{GK15, GK21} {
+		result, err := IntegrateGaussKronrod(f, a, b, e, rule, 100)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if math.Abs(I-result.Value) > e {
+			t.Errorf("expected: %v, got: %v", I, result.Value)
+		}
+
+		// Особенности на концах отрезка: ∫[0,1] 1/√x dx = 2, ∫[0,1] ln x dx = -1
+		result, err = IntegrateGaussKronrod(func(x float64) float64 { return 1 / math.Sqrt(x) }, 0, 1, e, rule, 100)
+		if err != nil || math.Abs(result.Value-2) > e {
+			t.Errorf("expected: %v, got: %v (%v)", 2, result.Value, err)
+		}
+
+		result, err = IntegrateGaussKronrod(math.Log, 0, 1, e, rule, 100)
+		if err != nil || math.Abs(result.Value+1) > e {
+			t.Errorf("expected: %v, got: %v (%v)", -1, result.Value, err)
+		}
+	}
+
+	// Узкий пик требует дробления только вблизи пика
+	spike := func(x float64) float64 {
+		return 1e-4 / ((x-0.3)*(x-0.3) + 1e-8)
+	}
+	exact := math.Atan(0.7e4) + math.Atan(0.3e4)
+	result, err := IntegrateGaussKronrod(spike, 0, 1, e, GK21, 200)
+	if err != nil || math.Abs(result.Value-exact) > e {
+		t.Errorf("expected: %v, got: %v (%v)", exact, result.Value, err)
+	}
+	if result.Evaluations > 5000 {
+		t.Errorf("too many evaluations: %v", result.Evaluations)
+	}
+
+	// Отрезок из двух ulp делится один раз, после чего деление невозможно, а при e = 0 точность недостижима
+	right := math.Nextafter(math.Nextafter(1, 2), 2)
+	if result, err := IntegrateGaussKronrod(math.Exp, 1, right, 0, GK15, 100); err != ErrRoundoff || result.Intervals != 2 {
+		t.Errorf("expected: %v, got: %v after %d intervals", ErrRoundoff, err, result.Intervals)
+	}
+	vector := func(x float64) []float64 { return []float64{math.Exp(x), x} }
+	if result, err := IntegrateGaussKronrodVector(vector, 1, right, 0, GK15, 100); err != ErrRoundoff {
+		t.Errorf("vector: expected: %v, got: %v after %d intervals", ErrRoundoff, err, result.Intervals)
+	}
+}
+
+func TestWynnEpsilon(t *testing.T) {
+	// Частичные суммы ряда ln 2 = 1 - 1/2 + 1/3 - ...
+	seq := make([]float64, 11)
+	sum := 0.0
+	for k := range seq {
+		sum += math.Pow(-1, float64(k)) / float64(k+1)
+		seq[k] = sum
+	}
+	if result := wynnEpsilon(seq); math.Abs(result-math.Ln2) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", math.Ln2, result)
+	}
+
+	// Нулевая разность в нулевом столбце: результат - последний элемент последовательности, а не старая сумма
+	if result := wynnEpsilon([]float64{1, 1, 5}); result != 5 {
+		t.Errorf("expected: %v, got: %v", 5, result)
+	}
+	// Нулевая разность в первом (вспомогательном) столбце: ε1 = {1, 1, 1}
+	if result := wynnEpsilon([]float64{0, 1, 2, 3}); result != 3 {
+		t.Errorf("expected: %v, got: %v", 3, result)
+	}
+	// Нулевая разность во втором столбце после вычисления первого четного столбца
+	if result := wynnEpsilon([]float64{1, 0.5, 0.75, 0.625, 0.6875}); math.Abs(result-2.0/3) > 1e-15 {
+		t.Errorf("expected: %v, got: %v", 2.0/3, result)
+	}
+}
+
+func TestIntegrateRomberg(t *testing.T) {
+	resultRomberg, tableau := IntegrateRomberg(f, a, b, e, nil)
+
+	if math.Abs(I-resultRomberg) > e {
+		t.Errorf("expected: %v, got: %v", I, resultRomberg)
+	}
+
+	// Первый столбец таблицы - формулы трапеций с удвоением числа отрезков
+	for k, row := range tableau {
+		if len(row) != k+1 {
+			t.Fatalf("row %d has %d elements", k, len(row))
+		}
+		if q := trapezoidalRule(f, a, b, 1<<uint(k), 1, tools.NeumaierSum); math.Abs(q-row[0]) > 1e-14 {
+			t.Errorf("level %d: expected: %v, got: %v", k, q, row[0])
+		}
+	}
+}
+
+func TestIntegrateBudget(t *testing.T) {
+	calls := 0
+	counted := func(x float64) float64 {
+		calls++
+		return f(x)
+	}
+
+	ctx := context.Background()
+	for name, method := range map[string]func(integrand, float64, float64, float64, Options) (Result, error){
+		"trapezoidal": func(f integrand, a, b, e float64, opts Options) (Result, error) {
+			return IntegrateTrapezoidalWithOptions(ctx, f, a, b, e, opts)
+		},
+		"simpson": func(f integrand, a, b, e float64, opts Options) (Result, error) {
+			return IntegrateSimpsonWithOptions(ctx, f, a, b, e, opts)
+		},
+		"romberg": func(f integrand, a, b, e float64, opts Options) (Result, error) {
+			return IntegrateRombergWithOptions(ctx, f, a, b, e, opts)
+		},
+		"clenshaw-curtis": func(f integrand, a, b, e float64, opts Options) (Result, error) {
+			return IntegrateClenshawCurtisAdaptiveWithOptions(ctx, f, a, b, e, opts)
+		},
+	} {
+		calls = 0
+		result, err := method(counted, a, b, e, Options{})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if math.Abs(I-result.Value) > e || result.Error > e {
+			t.Errorf("%s: expected: %v, got: %+v", name, I, result)
+		}
+
+		// Каждая точка вычисляется ровно один раз
+		if calls != result.Evaluations || calls != result.Intervals+1 {
+			t.Errorf("%s: %d calls, %d evaluations reported, %d intervals", name, calls, result.Evaluations, result.Intervals)
+		}
+
+		calls = 0
+		result, err = method(counted, a, b, e, Options{MaxEvals: 8})
+		if err != ErrEvalBudget {
+			t.Errorf("%s: expected: %v, got: %v", name, ErrEvalBudget, err)
+		}
+		if calls > 8 || calls != result.Evaluations {
+			t.Errorf("%s: budget exceeded: %d calls", name, calls)
+		}
+	}
+}
+
+func TestIntegrateImproper(t *testing.T) {
+	tests := []struct {
+		name  string
+		f     integrand
+		a, b  float64
+		exact float64
+	}{
+		{"exp(-x) on [0, ∞)", func(x float64) float64 { return math.Exp(-x) }, 0, math.Inf(1), 1},
+		{"1/(1+x²) on (-∞, ∞)", func(x float64) float64 { return 1 / (1 + x*x) }, math.Inf(-1), math.Inf(1), math.Pi},
+		{"exp(x) on (-∞, 1]", math.Exp, math.Inf(-1), 1, math.E},
+		{"exp(-x²) on (-∞, ∞)", func(x float64) float64 { return math.Exp(-x * x) }, math.Inf(-1), math.Inf(1), math.Sqrt(math.Pi)},
+		{"1/x² on [1, ∞) reversed", func(x float64) float64 { return 1 / (x * x) }, math.Inf(1), 1, -1},
+		{"exp(-x)·sin(x) on [0, ∞)", func(x float64) float64 { return math.Exp(-x) * math.Sin(x) }, 0, math.Inf(1), 0.5},
+	}
+
+	for _, test := range tests {
+		result, err := IntegrateImproper(test.f, test.a, test.b, e, 200)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if math.Abs(test.exact-result.Value) > e {
+			t.Errorf("%s: expected: %v, got: %v", test.name, test.exact, result.Value)
+		}
+	}
+
+	if _, err := IntegrateImproper(func(x float64) float64 { return 1 / (1 + x) }, 0, math.Inf(1), e, 200); err != ErrNotDecaying {
+		t.Errorf("expected: %v, got: %v", ErrNotDecaying, err)
+	}
+	if _, err := IntegrateImproper(math.Cos, math.Inf(-1), math.Inf(1), e, 200); err != ErrNotDecaying {
+		t.Errorf("expected: %v, got: %v", ErrNotDecaying, err)
+	}
+
+	// Осциллирующие хвосты отвергаются независимо от того, куда попадают точки проверки
+	for _, shift := range []float64{0, 0.3, 1, 2.5, 7} {
+		sinc := func(x float64) float64 { return math.Sin(x+shift) / x }
+		if _, err := IntegrateImproper(sinc, 1, math.Inf(1), e, 200); err != ErrNotDecaying {
+			t.Errorf("sin(x+%v)/x: expected: %v, got: %v", shift, ErrNotDecaying, err)
+		}
+		cosine := func(x float64) float64 { return math.Cos(x + shift) }
+		if _, err := IntegrateImproper(cosine, 0, math.Inf(1), e, 200); err != ErrNotDecaying {
+			t.Errorf("cos(x+%v): expected: %v, got: %v", shift, ErrNotDecaying, err)
+		}
+	}
+}
+
+func TestIntegrateTanhSinh(t *testing.T) {
+	resultTanhSinh, err := IntegrateTanhSinh(f, a, b, e, nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if math.Abs(I-resultTanhSinh.Value) > e {
+		t.Errorf("expected: %v, got: %v", I, resultTanhSinh.Value)
+	}
+
+	// Интегрируемые особенности на концах: ∫[0,1] 1/√x dx = 2, ∫[0,1] ln x dx = -1
+	result, err := IntegrateTanhSinh(func(x float64) float64 { return 1 / math.Sqrt(x) }, 0, 1, e, nil)
+	if err != nil || math.Abs(result.Value-2) > e {
+		t.Errorf("expected: %v, got: %v (%v)", 2, result.Value, err)
+	}
+
+	// Особенность на правом конце: узлы ближе ulp(1) к единице совпадают с ней, и оценка погрешности
+	// должна учитывать недоступную окрестность конца
+	right := func(x float64) float64 { return 1 / math.Sqrt(1-x) }
+	result, err = IntegrateTanhSinh(right, 0, 1, 1e-10, nil)
+	if err != ErrNotConverged || result.Error < math.Abs(result.Value-2) {
+		t.Errorf("expected: %v with error estimate >= %v, got: %v (%+v)", ErrNotConverged,
+			math.Abs(result.Value-2), err, result)
+	}
+
+	// Через точное расстояние до конца та же особенность вычисляется с заданной точностью
+	result, err = IntegrateTanhSinhComplement(func(x, xc float64) float64 {
+		if xc > 0 {
+			return 1 / math.Sqrt(xc)
+		}
+		return right(x)
+	}, 0, 1, 1e-10, nil)
+	if err != nil || math.Abs(result.Value-2) > 1e-10 {
+		t.Errorf("complement: expected: %v, got: %v (%v)", 2, result.Value, err)
+	}
+
+	// N - количество шагов по t, удваивающееся на каждом уровне
+	var recorder observer.Recorder
+	result, err = IntegrateTanhSinh(math.Log, 0, 1, e, &recorder)
+	if err != nil || math.Abs(result.Value+1) > e {
+		t.Errorf("expected: %v, got: %v (%v)", -1, result.Value, err)
+	}
+	steps := recorder.Steps
+	if len(steps) == 0 || steps[len(steps)-1].N != result.Intervals {
+		t.Fatalf("unexpected steps: %v", steps)
+	}
+	for i := 1; i < len(steps); i++ {
+		if steps[i].N != 2*steps[i-1].N {
+			t.Errorf("unexpected step %d: %v after %v", i, steps[i], steps[i-1])
+		}
+	}
+}
+
+func TestIntegrateObservers(t *testing.T) {
+	ctx := context.Background()
+	g := func(x float64) float64 { return math.Sqrt(x) * math.Sin(10*x) }
+
+	// Последний шаг каждого метода соответствует итоговому разбиению, N - количество подотрезков (параллелепипедов)
+	methods := map[string]func(opts Options) (Result, error){
+		"gauss-kronrod": func(opts Options) (Result, error) {
+			return IntegrateGaussKronrodWithOptions(ctx, g, 0, 1, 1e-10, GK21, 200, opts)
+		},
+		"filon": func(opts Options) (Result, error) {
+			return IntegrateFilonAdaptiveWithOptions(ctx, math.Exp, 0, 1, 50, Sine, 1e-10, opts)
+		},
+		"nested": func(opts Options) (Result, error) {
+			return IntegrateNestedWithOptions(ctx, func(x []float64) float64 {
+				return math.Exp(x[0] * x[1])
+			}, []float64{0, 0}, []float64{1, 1}, 1e-8, opts)
+		},
+		"genz-malik": func(opts Options) (Result, error) {
+			return IntegrateGenzMalikWithOptions(ctx, func(x []float64) float64 {
+				return math.Exp(x[0] * x[1])
+			}, []float64{0, 0}, []float64{1, 1}, 1e-8, opts)
+		},
+		"improper": func(opts Options) (Result, error) {
+			return IntegrateImproperWithOptions(ctx, func(x float64) float64 {
+				return math.Exp(-x * x)
+			}, math.Inf(-1), math.Inf(1), 1e-10, 100, opts)
+		},
+	}
+	for name, method := range methods {
+		var recorder observer.Recorder
+		result, err := method(Options{Observer: &recorder})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		steps := recorder.Steps
+		if len(steps) == 0 || steps[len(steps)-1].N != result.Intervals {
+			t.Errorf("%s: unexpected steps: %v, result: %+v", name, steps, result)
+		}
+
+		// Наблюдатель не влияет на результат
+		if plain, _ := method(Options{}); plain != result {
+			t.Errorf("%s: expected: %+v, got: %+v", name, plain, result)
+		}
+	}
+
+	var recorder observer.Recorder
+	result, err := IntegrateSimpsonVectorWithOptions(ctx, func(x float64) []float64 {
+		return []float64{f(x), math.Exp(x)}
+	}, a, b, e, Options{Observer: &recorder})
+	if last := recorder.Steps[len(recorder.Steps)-1]; err != nil || last.N != result.Intervals ||
+		last.Estimate != tools.UniformNorm(result.Value) || last.Error != result.Error {
+		t.Errorf("simpson vector: unexpected last step: %+v, result: %+v (%v)", last, result, err)
+	}
+}
+
+func TestIntegrateClenshawCurtis(t *testing.T) {
+	// Формулы с n+1 и n узлами точны для многочленов степени n
+	p := func(x float64) float64 {
+		return 8 * math.Pow(x, 7)
+	}
+	for _, n := range []int{7, 8, 12, 16} {
+		if result := IntegrateClenshawCurtis(p, 0, 1, n); math.Abs(result-1) > 1e-13 {
+			t.Errorf("Clenshaw-Curtis n = %d: expected: %v, got: %v", n, 1, result)
+		}
+		if result := IntegrateFejer(p, 0, 1, n+1); math.Abs(result-1) > 1e-13 {
+			t.Errorf("Fejer n = %d: expected: %v, got: %v", n+1, 1, result)
+		}
+	}
+
+	resultClenshawCurtis, estimate := IntegrateClenshawCurtisAdaptive(f, a, b, e, nil)
+	if math.Abs(I-resultClenshawCurtis) > e {
+		t.Errorf("expected: %v, got: %v", I, resultClenshawCurtis)
+	}
+	if estimate > e {
+		t.Errorf("error estimate %v exceeds tolerance", estimate)
+	}
+}
+
+func TestCubature(t *testing.T) {
+	// ∫∫[0,1]×[0,2] e^(x+y) dx dy = (e - 1)(e² - 1)
+	g2 := func(x, y float64) float64 {
+		return math.Exp(x + y)
+	}
+	exact2 := (math.E - 1) * (math.E*math.E - 1)
+	if result := IntegrateGaussLegendre2D(g2, 0, 1, 0, 2, 10); math.Abs(result-exact2) > e {
+		t.Errorf("expected: %v, got: %v", exact2, result)
+	}
+
+	// ∫[0,1]^d Π cos(xk) dx = sin(1)^d
+	g := func(x []float64) float64 {
+		p := 1.0
+		for _, v := range x {
+			p *= math.Cos(v)
+		}
+		return p
+	}
+	for _, d := range []int{2, 3, 4} {
+		lo, hi := make([]float64, d), make([]float64, d)
+		for k := range hi {
+			hi[k] = 1
+		}
+		exact := math.Pow(math.Sin(1), float64(d))
+
+		if result := IntegrateGaussLegendreND(g, lo, hi, 8); math.Abs(result-exact) > e {
+			t.Errorf("Gauss-Legendre d = %d: expected: %v, got: %v", d, exact, result)
+		}
+
+		result, err := IntegrateNested(g, lo, hi, e)
+		if err != nil || math.Abs(result.Value-exact) > e {
+			t.Errorf("nested d = %d: expected: %v, got: %v (%v)", d, exact, result.Value, err)
+		}
+
+		result, err = IntegrateGenzMalik(g, lo, hi, e, 0)
+		if err != nil || math.Abs(result.Value-exact) > e {
+			t.Errorf("Genz-Malik d = %d: expected: %v, got: %v (%v)", d, exact, result.Value, err)
+		}
+	}
+
+	// Правило Генца-Малика точно для многочленов степени 7
+	poly := func(x []float64) float64 {
+		return math.Pow(x[0], 4)*x[1]*x[1] + math.Pow(x[2], 6)
+	}
+	result, err := IntegrateGenzMalik(poly, []float64{0, 0, 0}, []float64{1, 1, 1}, 1, 0)
+	if err != nil || result.Intervals != 1 || math.Abs(result.Value-(1.0/15+1.0/7)) > 1e-14 {
+		t.Errorf("expected: %v, got: %v (%v)", 1.0/15+1.0/7, result, err)
+	}
+
+	if _, err := IntegrateGenzMalik(g, []float64{0, 0}, []float64{1, 1}, 1e-15, 100); err != ErrEvalBudget {
+		t.Errorf("expected: %v, got: %v", ErrEvalBudget, err)
+	}
+}
+
+func TestMonteCarlo(t *testing.T) {
+	// ∫[0,1]^6 Σ xk² dx = 2
+	d := 6
+	lo, hi := make([]float64, d), make([]float64, d)
+	for k := range hi {
+		hi[k] = 1
+	}
+	g := func(x []float64) float64 {
+		s := 0.0
+		for _, v := range x {
+			s += v * v
+		}
+		return s
+	}
+
+	plain := IntegrateMonteCarlo(g, lo, hi, 100000, 1, 1)
+	if math.Abs(plain.Value-2) > 5*plain.Error || plain.Error > 1e-2 {
+		t.Errorf("Monte Carlo: expected: %v, got: %v ± %v", 2, plain.Value, plain.Error)
+	}
+	if parallel := IntegrateMonteCarlo(g, lo, hi, 100000, 1, 4); parallel != plain {
+		t.Errorf("Monte Carlo result depends on the number of workers: %v != %v", parallel, plain)
+	}
+
+	stratified := IntegrateStratified(g, lo, hi, 3, 137, 1, 0)
+	if math.Abs(stratified.Value-2) > 5*stratified.Error || stratified.Error > plain.Error {
+		t.Errorf("stratified: expected: %v, got: %v ± %v", 2, stratified.Value, stratified.Error)
+	}
+
+	// ∫[0,∞) x² e^(-x) dx = 2 с выборкой из экспоненциального распределения
+	importance := IntegrateImportance(func(x []float64) float64 {
+		return x[0] * x[0] * math.Exp(-x[0])
+	}, 1, func(r *rand.Rand, x []float64) float64 {
+		x[0] = r.ExpFloat64()
+		return math.Exp(-x[0])
+	}, 100000, 1, 0)
+	if math.Abs(importance.Value-2) > 5*importance.Error {
+		t.Errorf("importance: expected: %v, got: %v ± %v", 2, importance.Value, importance.Error)
+	}
+
+	for _, kind := range []QuasiRandom{Sobol, Halton} {
+		result := IntegrateQuasiMonteCarlo(g, lo, hi, 1<<12, kind, 8, 1, 0)
+		if math.Abs(result.Value-2) > 1e-3 || result.Error > plain.Error/10 {
+			t.Errorf("quasi-Monte Carlo %d: expected: %v, got: %v ± %v", kind, 2, result.Value, result.Error)
+		}
+	}
+
+	// Потоки порций соседних seed не совпадают (при seed + i порция 1 при seed = s совпала бы с порцией 0 при s + 1)
+	for _, s := range []int64{0, 1, 41} {
+		for i := 0; i < 3; i++ {
+			r1, r2 := newStream(s, i+1), newStream(s+1, i)
+			same := 0
+			for j := 0; j < 100; j++ {
+				if r1.Float64() == r2.Float64() {
+					same++
+				}
+			}
+			if same > 0 {
+				t.Errorf("seed %d chunk %d and seed %d chunk %d share %d values", s, i+1, s+1, i, same)
+			}
+		}
+	}
+	if x, y := newStream(7, 3).Float64(), newStream(7, 3).Float64(); x != y {
+		t.Errorf("stream is not reproducible: %v != %v", x, y)
+	}
+	if shifted := IntegrateMonteCarlo(g, lo, hi, 100000, 2, 1); shifted == plain {
+		t.Errorf("Monte Carlo results for adjacent seeds coincide: %v", shifted)
+	}
+
+	// Первые 2^m точек каждой координаты последовательности Соболя попадают по одной в отрезки длины 2^(-m)
+	seq := NewSobolSequence(21)
+	x := make([]float64, 21)
+	seen := make([]map[int]bool, 21)
+	for k := range seen {
+		seen[k] = map[int]bool{}
+	}
+	for i := 0; i < 256; i++ {
+		seq.Next(x)
+		for k, v := range x {
+			seen[k][int(v*256)] = true
+		}
+	}
+	for k := range seen {
+		if len(seen[k]) != 256 {
+			t.Errorf("Sobol coordinate %d is not stratified: %d of 256 cells", k, len(seen[k]))
+		}
+	}
+}
+
+func TestIntegrateSimplex(t *testing.T) {
+	factorial := func(n int) float64 {
+		return math.Gamma(float64(n) + 1)
+	}
+
+	// ∫ x^i y^j по треугольнику (0,0), (1,0), (0,1) равен i! j! / (i+j+2)!
+	for degree := 1; degree <= 12; degree++ {
+		for i := 0; i <= degree; i++ {
+			j := degree - i
+			exact := factorial(i) * factorial(j) / factorial(i+j+2)
+			result := IntegrateTriangle(func(x, y float64) float64 {
+				return math.Pow(x, float64(i)) * math.Pow(y, float64(j))
+			}, [2]float64{0, 0}, [2]float64{1, 0}, [2]float64{0, 1}, degree)
+			if math.Abs(result-exact) > 1e-14 {
+				t.Errorf("triangle x^%d y^%d: expected: %v, got: %v", i, j, exact, result)
+			}
+		}
+	}
+
+	// ∫ x^i y^j z^k по тетраэдру (0,0,0), (1,0,0), (0,1,0), (0,0,1) равен i! j! k! / (i+j+k+3)!
+	for degree := 1; degree <= 10; degree++ {
+		for i := 0; i <= degree; i++ {
+			for j := 0; i+j <= degree; j++ {
+				k := degree - i - j
+				exact := factorial(i) * factorial(j) * factorial(k) / factorial(degree+3)
+				result := IntegrateTetrahedron(func(x, y, z float64) float64 {
+					return math.Pow(x, float64(i)) * math.Pow(y, float64(j)) * math.Pow(z, float64(k))
+				}, [3]float64{0, 0, 0}, [3]float64{1, 0, 0}, [3]float64{0, 1, 0}, [3]float64{0, 0, 1}, degree)
+				if math.Abs(result-exact) > 1e-14 {
+					t.Errorf("tetrahedron x^%d y^%d z^%d: expected: %v, got: %v", i, j, k, exact, result)
+				}
+			}
+		}
+	}
+
+	// Произвольный треугольник с вершинами в другом порядке: ∫ e^x sin y сравниваем с формулой Даффи высокой степени
+	p1, p2, p3 := [2]float64{1, 0}, [2]float64{3, 1}, [2]float64{2, 4}
+	g := func(x, y float64) float64 {
+		return math.Exp(x) * math.Sin(y)
+	}
+	exact := IntegrateTriangleDuffy(g, p1, p2, p3, 30)
+	if result := IntegrateTriangle(g, p3, p1, p2, 8); math.Abs(result-exact) > 1e-5*math.Abs(exact) {
+		t.Errorf("expected: %v, got: %v", exact, result)
+	}
+}
+
+func TestIntegrateSamples(t *testing.T) {
+	// Неравномерная сетка на [0, 2]: узлы сгущаются к левому концу
+	samples := func(g func(float64) float64, n int) []node.Node {
+		nodes := make([]node.Node, n+1)
+		for i := range nodes {
+			x := 2 * math.Pow(float64(i)/float64(n), 1.5)
+			nodes[i] = node.Node{X: x, Y: g(x)}
+		}
+		return nodes
+	}
+
+	// Формула Симпсона точна для квадратичных функций на любой сетке при любом числе отрезков
+	square := func(x float64) float64 { return 3*x*x + 1 }
+	for _, n := range []int{1, 2, 7, 10} {
+		expected := 10.0
+		if n == 1 {
+			expected = 14 // для двух узлов - формула трапеций
+		}
+		if result := IntegrateSimpsonSamples(samples(square, n)); math.Abs(result-expected) > 1e-12 {
+			t.Errorf("n = %d: expected: %v, got: %v", n, expected, result)
+		}
+	}
+
+	// ∫[0,2] e^x dx = e² - 1
+	exact := math.Exp(2) - 1
+	nodes := samples(math.Exp, 41)
+	if result := IntegrateTrapezoidalSamples(nodes); math.Abs(result-exact) > 1e-2 {
+		t.Errorf("trapezoid: expected: %v, got: %v", exact, result)
+	}
+	if result := IntegrateSimpsonSamples(nodes); math.Abs(result-exact) > 1e-5 {
+		t.Errorf("Simpson: expected: %v, got: %v", exact, result)
+	}
+	if result := IntegrateSplineSamples(nodes); math.Abs(result-exact) > 1e-5 {
+		t.Errorf("spline: expected: %v, got: %v", exact, result)
+	}
+
+	cumulative := IntegrateCumulative(nodes)
+	for i, nd := range nodes {
+		if math.Abs(cumulative[i]-(math.Exp(nd.X)-1)) > 1e-2 {
+			t.Errorf("cumulative at %v: expected: %v, got: %v", nd.X, math.Exp(nd.X)-1, cumulative[i])
+		}
+	}
+}
+
+func TestIntegrateOscillatory(t *testing.T) {
+	// ∫[0,1] e^x e^(iωx) dx = (e^(1+iω) - 1) / (1 + iω)
+	for _, omega := range []float64{1, 100, 10000} {
+		z := (cmplx.Exp(complex(1, omega)) - 1) / complex(1, omega)
+		exactCos, exactSin := real(z), imag(z)
+
+		if result := IntegrateFilon(math.Exp, 0, 1, omega, Cosine, 64); math.Abs(result-exactCos) > e {
+			t.Errorf("Filon cos ω = %v: expected: %v, got: %v", omega, exactCos, result)
+		}
+
+		result, err := IntegrateFilonAdaptive(math.Exp, 0, 1, omega, Sine, e)
+		if err != nil || math.Abs(result.Value-exactSin) > e || result.Evaluations > 600 {
+			t.Errorf("adaptive Filon sin ω = %v: expected: %v, got: %v (%v)", omega, exactSin, result, err)
+		}
+
+		if omega < 100 {
+			continue
+		}
+		cos, sin, err := IntegrateLevin(math.Exp, 0, 1, omega, 10)
+		if err != nil || math.Abs(cos-exactCos) > e || math.Abs(sin-exactSin) > e {
+			t.Errorf("Levin ω = %v: expected: %v, %v, got: %v, %v (%v)", omega, exactCos, exactSin, cos, sin, err)
+		}
+	}
+
+	// При ω = 0 система коллокации вырождена
+	if _, _, err := IntegrateLevin(math.Exp, 0, 1, 0, 10); !errors.Is(err, equations.ErrSingular) {
+		t.Errorf("Levin ω = 0: expected: %v, got: %v", equations.ErrSingular, err)
+	}
+}
+
+func TestIntegrateContext(t *testing.T) {
+	// Отмена во время вычислений: метод прерывается, возвращая последнее приближение
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	cancelling := func(x float64) float64 {
+		calls++
+		if calls == 50 {
+			cancel()
+		}
+		return f(x)
+	}
+
+	result, err := IntegrateTrapezoidalContext(ctx, cancelling, a, b, 1e-14, nil)
+	if err != context.Canceled {
+		t.Errorf("trapezoidal: expected: %v, got: %v", context.Canceled, err)
+	}
+	if result.Evaluations == 0 || result.Evaluations != calls || math.Abs(I-result.Value) > 1e-2 {
+		t.Errorf("trapezoidal: unexpected partial result %+v after %d calls", result, calls)
+	}
+
+	// Уже отмененный контекст
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := IntegrateSimpsonContext(ctx, f, a, b, e, nil); err != context.Canceled {
+		t.Errorf("simpson: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateGaussKronrodContext(ctx, math.Sqrt, 0, 1, e, GK21, 100); err != context.Canceled {
+		t.Errorf("gauss-kronrod: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateImproperContext(ctx, math.Sqrt, 0, 1, e, 100); err != context.Canceled {
+		t.Errorf("improper: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateGaussLegendreAdaptiveContext(ctx, f, a, b, 3, e, nil); err != context.Canceled {
+		t.Errorf("gauss-legendre: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateClenshawCurtisAdaptiveContext(ctx, f, a, b, e, nil); err != context.Canceled {
+		t.Errorf("clenshaw-curtis: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateRombergContext(ctx, f, a, b, e, nil); err != context.Canceled {
+		t.Errorf("romberg: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateTanhSinhContext(ctx, f, a, b, e, nil); err != context.Canceled {
+		t.Errorf("tanh-sinh: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateFilonAdaptiveContext(ctx, math.Exp, 0, 1, 50, Sine, e); err != context.Canceled {
+		t.Errorf("filon: expected: %v, got: %v", context.Canceled, err)
+	}
+
+	g := func(x []float64) float64 { return math.Sqrt(x[0] + x[1]) }
+	if _, err := IntegrateNestedContext(ctx, g, []float64{0, 0}, []float64{1, 1}, e); err != context.Canceled {
+		t.Errorf("nested: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateGenzMalikContext(ctx, g, []float64{0, 0}, []float64{1, 1}, e, 0); err != context.Canceled {
+		t.Errorf("genz-malik: expected: %v, got: %v", context.Canceled, err)
+	}
+
+	// Методы Монте-Карло проверяют ctx перед каждой порцией точек (ячейкой, повторением)
+	lo, hi := []float64{0, 0}, []float64{1, 1}
+	if result, err := IntegrateMonteCarloContext(ctx, g, lo, hi, 1000, 1, 0); err != context.Canceled ||
+		result.Evaluations != 0 {
+		t.Errorf("monte carlo: expected: %v, got: %v (%d evaluations)", context.Canceled, err, result.Evaluations)
+	}
+	if result, err := IntegrateStratifiedContext(ctx, g, lo, hi, 4, 8, 1, 0); err != context.Canceled ||
+		result.Intervals != 0 {
+		t.Errorf("stratified: expected: %v, got: %v (%d cells)", context.Canceled, err, result.Intervals)
+	}
+	if _, err := IntegrateImportanceContext(ctx, g, 2, func(r *rand.Rand, x []float64) float64 {
+		x[0], x[1] = r.Float64(), r.Float64()
+		return 1
+	}, 1000, 1, 0); err != context.Canceled {
+		t.Errorf("importance: expected: %v, got: %v", context.Canceled, err)
+	}
+	if _, err := IntegrateQuasiMonteCarloContext(ctx, g, lo, hi, 1000, Sobol, 4, 1, 0); err != context.Canceled {
+		t.Errorf("quasi-monte carlo: expected: %v, got: %v", context.Canceled, err)
+	}
+
+	// Отмена во время первой порции: остальные порции пропускаются, оценка строится по первой
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	calls = 0
+	mc, err := IntegrateMonteCarloContext(ctx, func(x []float64) float64 {
+		if calls++; calls == 10 {
+			cancel()
+		}
+		return g(x)
+	}, lo, hi, 3*monteCarloChunk, 1, 1)
+	if first := IntegrateMonteCarlo(g, lo, hi, monteCarloChunk, 1, 1); err != context.Canceled || mc != first {
+		t.Errorf("monte carlo: expected: %+v, got: %+v (%v)", first, mc, err)
+	}
+}
+
+func TestIntegrateVector(t *testing.T) {
+	// Компоненты: f, sin(x), e^x на [a, b]
+	exact := []float64{I, 1 - math.Cos(b), math.Exp(b) - 1}
+	buf := make([]float64, 3)
+	calls := 0
+	g := func(x float64) []float64 {
+		calls++
+		buf[0], buf[1], buf[2] = f(x), math.Sin(x), math.Exp(x)
+		return buf
+	}
+
+	for name, method := range map[string]func() (VectorResult, error){
+		"gauss-kronrod": func() (VectorResult, error) { return IntegrateGaussKronrodVector(g, a, b, e, GK21, 100) },
+		"simpson":       func() (VectorResult, error) { return IntegrateSimpsonVector(g, a, b, e) },
+	} {
+		calls = 0
+		result, err := method()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		for k := range exact {
+			if math.Abs(exact[k]-result.Value[k]) > e {
+				t.Errorf("%s: component %d: expected: %v, got: %v", name, k, exact[k], result.Value[k])
+			}
+		}
+		if calls != result.Evaluations || result.Error > e {
+			t.Errorf("%s: %d calls, result %+v", name, calls, result)
+		}
+	}
+
+	// Компоненты с разной гладкостью делят общее разбиение
+	result, err := IntegrateGaussKronrodVector(func(x float64) []float64 {
+		return []float64{math.Sqrt(x), x * x}
+	}, 0, 1, 1e-10, GK15, 200)
+	if err != nil || math.Abs(result.Value[0]-2.0/3) > 1e-10 || math.Abs(result.Value[1]-1.0/3) > 1e-14 {
+		t.Errorf("unexpected result %+v, error %v", result, err)
+	}
+
+	// ∫ e^(ix) dx от 0 до π = 2i
+	h := func(x float64) complex128 { return cmplx.Exp(complex(0, x)) }
+	c, err := IntegrateGaussKronrodComplex(h, 0, math.Pi, e, GK21, 100)
+	if err != nil || cmplx.Abs(c.Value-2i) > e {
+		t.Errorf("gauss-kronrod: expected: %v, got: %v (%v)", 2i, c.Value, err)
+	}
+	c, err = IntegrateSimpsonComplex(h, 0, math.Pi, e)
+	if err != nil || cmplx.Abs(c.Value-2i) > e {
+		t.Errorf("simpson: expected: %v, got: %v (%v)", 2i, c.Value, err)
+	}
+
+	if _, err := IntegrateSimpsonVectorWithOptions(context.Background(), g, a, b, e, Options{MaxEvals: 20}); err != ErrEvalBudget {
+		t.Errorf("expected: %v, got: %v", ErrEvalBudget, err)
+	}
+
+	// Вектор-функция нулевой размерности отвергается до оценки погрешности
+	empty := func(float64) []float64 { return nil }
+	for name, method := range map[string]func(){
+		"gauss-kronrod": func() { IntegrateGaussKronrodVector(empty, a, b, e, GK21, 100) },
+		"simpson":       func() { IntegrateSimpsonVector(empty, a, b, e) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "integral: vector integrand must return a non-empty slice" {
+					t.Errorf("%s: unexpected panic: %v", name, r)
+				}
+			}()
+			method()
+		}()
+	}
+}
+
+func TestIntegrateParallel(t *testing.T) {
+	ctx := context.Background()
+	g := func(x float64) float64 { return math.Sqrt(x) * math.Sin(10*x) }
+
+	serial, err := IntegrateTrapezoidalContext(ctx, f, a, b, e, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	simpson, _ := IntegrateSimpsonContext(ctx, f, a, b, e, nil)
+	kronrod, _ := IntegrateGaussKronrodContext(ctx, g, 0, 1, 1e-12, GK21, 200)
+
+	// Результат побитово совпадает с последовательным при любом числе потоков
+	for _, workers := range []int{0, 1, 2, 3, 8} {
+		if result, _ := IntegrateTrapezoidalWithOptions(ctx, f, a, b, e, Options{Workers: workers}); result != serial {
+			t.Errorf("trapezoidal, %d workers: expected: %+v, got: %+v", workers, serial, result)
+		}
+		if result, _ := IntegrateSimpsonWithOptions(ctx, f, a, b, e, Options{Workers: workers}); result != simpson {
+			t.Errorf("simpson, %d workers: expected: %+v, got: %+v", workers, simpson, result)
+		}
+		if result, _ := IntegrateGaussKronrodWithOptions(ctx, g, 0, 1, 1e-12, GK21, 200, Options{Workers: workers}); result != kronrod {
+			t.Errorf("gauss-kronrod, %d workers: expected: %+v, got: %+v", workers, kronrod, result)
+		}
+	}
+
+	// Остальные адаптивные методы также вычисляют f пакетами на каждом уровне
+	methods := map[string]func(workers int) []float64{
+		"romberg": func(workers int) []float64 {
+			result, _ := IntegrateRombergWithOptions(ctx, f, a, b, e, Options{Workers: workers})
+			return []float64{result.Value, result.Error, float64(result.Evaluations)}
+		},
+		"gauss-legendre": func(workers int) []float64 {
+			result, _ := IntegrateGaussLegendreAdaptiveWithOptions(ctx, g, 0, 1, 4, 1e-10, Options{Workers: workers})
+			return []float64{result.Value, result.Error, float64(result.Evaluations)}
+		},
+		"clenshaw-curtis": func(workers int) []float64 {
+			result, _ := IntegrateClenshawCurtisAdaptiveWithOptions(ctx, g, 0, 1, 1e-10, Options{Workers: workers})
+			return []float64{result.Value, result.Error, float64(result.Evaluations)}
+		},
+		"filon": func(workers int) []float64 {
+			result, _ := IntegrateFilonAdaptiveWithOptions(ctx, math.Exp, 0, 1, 50, Sine, 1e-10, Options{Workers: workers})
+			return []float64{result.Value, result.Error, float64(result.Evaluations)}
+		},
+		"nested": func(workers int) []float64 {
+			result, _ := IntegrateNestedWithOptions(ctx, func(x []float64) float64 {
+				return math.Exp(x[0] * x[1])
+			}, []float64{0, 0}, []float64{1, 1}, 1e-8, Options{Workers: workers})
+			return []float64{result.Value, result.Error, float64(result.Evaluations)}
+		},
+		"gauss-kronrod vector": func(workers int) []float64 {
+			result, _ := IntegrateGaussKronrodVectorWithOptions(ctx, func(x float64) []float64 {
+				return []float64{g(x), math.Exp(x)}
+			}, 0, 1, 1e-12, GK21, 200, Options{Workers: workers})
+			return append(append(result.Value, result.Errors...), float64(result.Evaluations))
+		},
+		"simpson vector": func(workers int) []float64 {
+			result, _ := IntegrateSimpsonVectorWithOptions(ctx, func(x float64) []float64 {
+				return []float64{f(x), math.Exp(x)}
+			}, a, b, e, Options{Workers: workers})
+			return append(append(result.Value, result.Errors...), float64(result.Evaluations))
+		},
+	}
+	for name, method := range methods {
+		serial := method(1)
+		for _, workers := range []int{0, 2, 3, 8} {
+			got := method(workers)
+			for i := range serial {
+				if got[i] != serial[i] {
+					t.Errorf("%s, %d workers: expected: %v, got: %v", name, workers, serial, got)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestIntegrateSummation(t *testing.T) {
+	// При 2^20 отрезках ошибка округления последовательного суммирования заметна, компенсированного - нет
+	constant := func(x float64) float64 { return 0.1 }
+	n := 1 << 20
+
+	naive := math.Abs(trapezoidalRule(constant, 0, 1, n, 1, tools.NaiveSum) - 0.1)
+	if naive < 1e-13 {
+		t.Errorf("naive summation error is unexpectedly small: %v", naive)
+	}
+	for _, s := range []tools.Summation{tools.KahanSum, tools.NeumaierSum, tools.PairwiseSum} {
+		if diff := math.Abs(trapezoidalRule(constant, 0, 1, n, 1, s) - 0.1); diff > 1e-16 || diff >= naive {
+			t.Errorf("summation %d: error %v, naive error %v", s, diff, naive)
+		}
+	}
+
+	// Сумма с сокращением слагаемых разных знаков
+	x := []float64{1, 1e100, 1, -1e100}
+	if sum := tools.SumNeumaier(x); sum != 2 {
+		t.Errorf("expected: %v, got: %v", 2, sum)
+	}
+	if sum := tools.DotProduct(x, []float64{1, 1, 1, 1}); sum != 2 {
+		t.Errorf("expected: %v, got: %v", 2, sum)
+	}
+
+	// Нулевое значение Summation - суммирование Ноймайера
+	var zero tools.Summation
+	if zero != tools.NeumaierSum {
+		t.Errorf("expected default summation %d, got: %d", tools.NeumaierSum, zero)
+	}
+
+	ctx := context.Background()
+	for _, s := range []tools.Summation{tools.NaiveSum, tools.KahanSum, tools.NeumaierSum, tools.PairwiseSum} {
+		opts := Options{Summation: s}
+		if result, err := IntegrateSimpsonWithOptions(ctx, f, a, b, e, opts); err != nil || math.Abs(I-result.Value) > e {
+			t.Errorf("simpson, summation %d: expected: %v, got: %v (%v)", s, I, result.Value, err)
+		}
+		if result, err := IntegrateClenshawCurtisAdaptiveWithOptions(ctx, f, a, b, e, opts); err != nil ||
+			math.Abs(I-result.Value) > e {
+			t.Errorf("clenshaw-curtis, summation %d: expected: %v, got: %v (%v)", s, I, result.Value, err)
+		}
+		if result, err := IntegrateGaussKronrodWithOptions(ctx, f, a, b, e, GK21, 100, opts); err != nil ||
+			math.Abs(I-result.Value) > e {
+			t.Errorf("gauss-kronrod, summation %d: expected: %v, got: %v (%v)", s, I, result.Value, err)
+		}
+	}
+}
+
+func TestIntegrateOptionsBudget(t *testing.T) {
+	ctx := context.Background()
+	g := func(x float64) float64 { return math.Sqrt(x) * math.Sin(10*x) }
+	opts := Options{MaxEvals: 50}
+
+	if result, err := IntegrateTrapezoidalWithOptions(ctx, f, a, b, 1e-14, opts); err != ErrEvalBudget ||
+		result.Evaluations > opts.MaxEvals {
+		t.Errorf("trapezoidal: expected: %v, got: %v (%d evaluations)", ErrEvalBudget, err, result.Evaluations)
+	}
+	if _, err := IntegrateRombergWithOptions(ctx, g, 0, 1, 1e-14, opts); err != ErrEvalBudget {
+		t.Errorf("romberg: expected: %v, got: %v", ErrEvalBudget, err)
+	}
+	if _, err := IntegrateGaussLegendreAdaptiveWithOptions(ctx, g, 0, 1, 4, 1e-14, opts); err != ErrEvalBudget {
+		t.Errorf("gauss-legendre: expected: %v, got: %v", ErrEvalBudget, err)
+	}
+	if _, err := IntegrateClenshawCurtisAdaptiveWithOptions(ctx, g, 0, 1, 1e-14, opts); err != ErrEvalBudget {
+		t.Errorf("clenshaw-curtis: expected: %v, got: %v", ErrEvalBudget, err)
+	}
+	if result, err := IntegrateGaussKronrodWithOptions(ctx, g, 0, 1, 1e-14, GK21, 200, opts); err != ErrEvalBudget ||
+		result.Evaluations > opts.MaxEvals {
+		t.Errorf("gauss-kronrod: expected: %v, got: %v (%d evaluations)", ErrEvalBudget, err, result.Evaluations)
+	}
+	if _, err := IntegrateFilonAdaptiveWithOptions(ctx, g, 0, 1, 50, Sine, 1e-14, opts); err != ErrEvalBudget {
+		t.Errorf("filon: expected: %v, got: %v", ErrEvalBudget, err)
+	}
+	if _, err := IntegrateNestedWithOptions(ctx, func(x []float64) float64 {
+		return math.Sqrt(x[0] * x[1])
+	}, []float64{0, 0}, []float64{1, 1}, 1e-14, Options{MaxEvals: 500}); err != ErrEvalBudget {
+		t.Errorf("nested: expected: %v, got: %v", ErrEvalBudget, err)
+	}
+	if result, err := IntegrateGaussKronrodVectorWithOptions(ctx, func(x float64) []float64 {
+		return []float64{g(x)}
+	}, 0, 1, 1e-14, GK21, 200, opts); err != ErrEvalBudget || result.Evaluations > opts.MaxEvals {
+		t.Errorf("gauss-kronrod vector: expected: %v, got: %v (%d evaluations)", ErrEvalBudget, err, result.Evaluations)
+	}
+}
+
+func TestQuadratureRules(t *testing.T) {
+	cubic := func(x float64) float64 { return x*x*x - 2*x + 1 }
+	exact := (math.Pow(3, 4)-1)/4 - (9 - 1) + 2 // ∫[1,3]
+
+	for name, rule := range map[string]QuadratureRule{
+		"simpson":         SimpsonNodes,
+		"clenshaw-curtis": ClenshawCurtisNodes,
+		"fejer":           FejerNodes,
+		"gauss-legendre":  node.BuildGaussLegendreNodes,
+	} {
+		nodes := rule(1, 3, 5)
+		sum := 0.0
+		for i, nd := range nodes {
+			if i > 0 && nd.X <= nodes[i-1].X {
+				t.Errorf("%s: nodes are not increasing", name)
+			}
+			sum += nd.Y * cubic(nd.X)
+		}
+		if math.Abs(sum-exact) > 1e-12 {
+			t.Errorf("%s: expected: %v, got: %v", name, exact, sum)
+		}
+	}
+
+	// Формула трапеций точна для линейных функций
+	sum := 0.0
+	for _, nd := range TrapezoidalNodes(1, 3, 4) {
+		sum += nd.Y * (2*nd.X + 1)
+	}
+	if math.Abs(sum-10) > 1e-14 {
+		t.Errorf("trapezoidal: expected: %v, got: %v", 10, sum)
+	}
+}
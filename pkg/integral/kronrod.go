@@ -0,0 +1,370 @@
+package integral
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// KronrodRule определяет пару вложенных квадратурных формул Гаусса-Кронрода
+type KronrodRule int
+
+const (
+	GK15 KronrodRule = iota // 7-точечная формула Гаусса и 15-точечная формула Кронрода
+	GK21                    // 10-точечная формула Гаусса и 21-точечная формула Кронрода
+)
+
+const (
+	machEps       = 2.220446049250313e-16 // машинный эпсилон для float64
+	wynnMaxLength = 50                    // максимальная длина последовательности для ε-алгоритма Винна
+)
+
+// ErrIntervalLimit возвращается, если заданная точность не достигнута за допустимое число подотрезков
+var ErrIntervalLimit = errors.New("integral: maximum number of subintervals reached")
+
+// ErrRoundoff возвращается, если точность не достигнута, потому что подотрезок с наибольшей погрешностью
+// нельзя делить дальше в арифметике с плавающей точкой (ошибки округления или неинтегрируемая особенность)
+var ErrRoundoff = errors.New("integral: subinterval cannot be bisected further due to roundoff")
+
+// Result представляет результат адаптивного интегрирования.
+type Result struct {
+	Value       float64 // Приближенное значение интеграла.
+	Error       float64 // Оценка абсолютной погрешности.
+	Evaluations int     // Количество вычислений подынтегральной функции.
+	Intervals   int     // Количество подотрезков итогового разбиения.
+}
+
+// kronrodTable узлы и веса формулы Гаусса-Кронрода на [-1, 1]
+// xgk - неотрицательные узлы Кронрода по убыванию (последний - центр), узлы с нечетными номерами - узлы Гаусса
+type kronrodTable struct {
+	xgk []float64 // Узлы формулы Кронрода.
+	wgk []float64 // Веса формулы Кронрода.
+	wg  []float64 // Веса формулы Гаусса для узлов xgk[1], xgk[3], ...
+}
+
+// Узлы и веса формул Гаусса-Кронрода (QUADPACK)
+var kronrodTables = map[KronrodRule]kronrodTable{
+	GK15: {
+		xgk: []float64{
+			0.991455371120812639206854697526329,
+			0.949107912342758524526189684047851,
+			0.864864423359769072789712788640926,
+			0.741531185599394439863864773280788,
+			0.586087235467691130294144845693013,
+			0.405845151377397166906606412076961,
+			0.207784955007898467600689403773245,
+			0.000000000000000000000000000000000,
+		},
+		wgk: []float64{
+			0.022935322010529224963732008058970,
+			0.063092092629978553290700663189204,
+			0.104790010322250183839876322541518,
+			0.140653259715525918745189590510238,
+			0.169004726639267902826583426598550,
+			0.190350578064785409913256402421014,
+			0.204432940075298892414161999234649,
+			0.209482141084727828012999174891714,
+		},
+		wg: []float64{
+			0.129484966168869693270611432679082,
+			0.279705391489276667901467771423780,
+			0.381830050505118944950369775488975,
+			0.417959183673469387755102040816327,
+		},
+	},
+	GK21: {
+		xgk: []float64{
+			0.995657163025808080735527280689003,
+			0.973906528517171720077964012084452,
+			0.930157491355708226001207180059508,
+			0.865063366688984510732096688423493,
+			0.780817726586416897063717578345042,
+			0.679409568299024406234327365114874,
+			0.562757134668604683339000099272694,
+			0.433395394129247190799265943165784,
+			0.294392862701460198131126603103866,
+			0.148874338981631210884826001129720,
+			0.000000000000000000000000000000000,
+		},
+		wgk: []float64{
+			0.011694638867371874278064396062192,
+			0.032558162307964727478818972459390,
+			0.054755896574351996031381300244580,
+			0.075039674810919952767043140916190,
+			0.093125454583697605535065465083366,
+			0.109387158802297641899210590325805,
+			0.123491976262065851077208980748956,
+			0.134709217311473325928054001771707,
+			0.142775938577060080797094273138717,
+			0.147739104901338491374841515972068,
+			0.149445554002916905664936468389821,
+		},
+		wg: []float64{
+			0.066671344308688137593568809893332,
+			0.149451349150580593145776339657697,
+			0.219086362515982043995534934228163,
+			0.269266719309996355091226921569469,
+			0.295524224714752870173892994651338,
+		},
+	},
+}
+
+// kronrodPoints возвращает узлы формулы Кронрода на [a, b]: центр, затем пары center ∓ half·xgk[j]
+func kronrodPoints(a, b float64, table kronrodTable) []float64 {
+	center := (a + b) / 2
+	half := (b - a) / 2
+	c := len(table.xgk) - 1
+
+	points := make([]float64, 0, 2*c+1)
+	points = append(points, center)
+	for j := 0; j < c; j++ {
+		dx := half * table.xgk[j]
+		points = append(points, center-dx, center+dx)
+	}
+
+	return points
+}
+
+// kronrodValues вычисляет интеграл по формуле Кронрода на [a, b] и оценку погрешности по разности с вложенной
+// формулой Гаусса (как в QUADPACK) по значениям f в узлах kronrodPoints
+func kronrodValues(values []float64, a, b float64, table kronrodTable) (float64, float64) {
+	c := len(table.xgk) - 1
+	fv1 := make([]float64, c)
+	fv2 := make([]float64, c)
+	for j := 0; j < c; j++ {
+		fv1[j] = values[2*j+1]
+		fv2[j] = values[2*j+2]
+	}
+
+	return kronrodEstimate(values[0], fv1, fv2, (b-a)/2, table)
+}
+
+// kronrodEstimate вычисляет по значениям функции значение формулы Кронрода и оценку погрешности
+// fc - значение в центре отрезка, fv1[j] и fv2[j] - значения в точках center ∓ half·xgk[j], half - половина длины
+func kronrodEstimate(fc float64, fv1, fv2 []float64, half float64, table kronrodTable) (float64, float64) {
+	c := len(table.xgk) - 1
+
+	resk := table.wgk[c] * fc
+	resg := 0.0
+	if c%2 == 1 {
+		resg = table.wg[c/2] * fc
+	}
+	resabs := math.Abs(resk)
+
+	for j := 0; j < c; j++ {
+		resk += table.wgk[j] * (fv1[j] + fv2[j])
+		resabs += table.wgk[j] * (math.Abs(fv1[j]) + math.Abs(fv2[j]))
+		if j%2 == 1 {
+			resg += table.wg[j/2] * (fv1[j] + fv2[j])
+		}
+	}
+
+	// resasc - оценка интеграла от |f - среднее|, используется для масштабирования погрешности
+	mean := resk / 2
+	resasc := table.wgk[c] * math.Abs(fc-mean)
+	for j := 0; j < c; j++ {
+		resasc += table.wgk[j] * (math.Abs(fv1[j]-mean) + math.Abs(fv2[j]-mean))
+	}
+
+	result := resk * half
+	resabs *= math.Abs(half)
+	resasc *= math.Abs(half)
+	err := math.Abs((resk - resg) * half)
+
+	if resasc != 0 && err != 0 {
+		err = resasc * math.Min(1, math.Pow(200*err/resasc, 1.5))
+	}
+	if resabs > math.SmallestNonzeroFloat64/(50*machEps) {
+		err = math.Max(50*machEps*resabs, err)
+	}
+
+	return result, err
+}
+
+// wynnEpsilon ускоряет сходимость последовательности частичных сумм ε-алгоритмом Винна
+// Возвращает последний элемент последнего вычисленного четного столбца ε-таблицы (при нулевой разности соседних
+// элементов таблица дальше не строится) или последний элемент seq, если четных столбцов, кроме нулевого, нет
+func wynnEpsilon(seq []float64) float64 {
+	n := len(seq)
+	prev := make([]float64, n+1) // ε(-1) = 0
+	cur := make([]float64, n)
+	copy(cur, seq)
+
+	best := seq[n-1]
+	for k := 1; k < n; k++ {
+		next := make([]float64, n-k)
+		for i := range next {
+			d := cur[i+1] - cur[i]
+			if d == 0 {
+				// Следующий столбец не определен. Элементы нечетных столбцов - вспомогательные величины,
+				// а не приближения предела, поэтому возвращается последний элемент четного столбца
+				return best
+			}
+			next[i] = prev[i+1] + 1/d
+		}
+		prev, cur = cur, next
+
+		if k%2 == 0 {
+			value := cur[len(cur)-1]
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				break
+			}
+			best = value
+		}
+	}
+
+	return best
+}
+
+// kronrodInterval подотрезок разбиения в адаптивном методе Гаусса-Кронрода
+type kronrodInterval struct {
+	a, b  float64
+	value float64
+	err   float64
+	depth int
+}
+
+// IntegrateGaussKronrod вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
+// глобально адаптивным методом Гаусса-Кронрода (по схеме QAGS из QUADPACK): на каждом шаге делится пополам
+// подотрезок с наибольшей оценкой погрешности, а последовательность приближений ускоряется ε-алгоритмом Винна,
+// что позволяет интегрировать функции с особенностями на концах отрезка
+// rule - пара формул, limit - максимальное количество подотрезков
+// Возвращает результат с оценкой погрешности и количеством вычислений f; если точность не достигнута,
+// возвращает лучшее найденное приближение и ErrIntervalLimit (или ErrRoundoff, если деление стало невозможным)
+func IntegrateGaussKronrod(f integrand, a, b float64, e float64, rule KronrodRule, limit int) (Result, error) {
+	return IntegrateGaussKronrodContext(context.Background(), f, a, b, e, rule, limit)
+}
+
+// IntegrateGaussKronrodContext работает как IntegrateGaussKronrod, но проверяет ctx перед каждым делением подотрезка
+// При отмене ctx или истечении его срока возвращает лучшее полученное приближение и ctx.Err()
+func IntegrateGaussKronrodContext(ctx context.Context, f integrand, a, b float64, e float64, rule KronrodRule,
+	limit int) (Result, error) {
+	return IntegrateGaussKronrodWithOptions(ctx, f, a, b, e, rule, limit, Options{})
+}
+
+// IntegrateGaussKronrodWithOptions работает как IntegrateGaussKronrodContext с параметрами opts (см. Options)
+// Узлы двух половин делимого подотрезка вычисляются одним пакетом в opts.Workers потоках; значения и оценки
+// погрешности подотрезков накапливаются по мере деления и в конце пересчитываются способом opts.Summation. После каждого деления opts.Observer получает
+// длину половин, сумму по подотрезкам, оценку погрешности и количество подотрезков. Если деление превысило бы
+// opts.MaxEvals вычислений f, возвращает лучшее приближение и ErrEvalBudget
+func IntegrateGaussKronrodWithOptions(ctx context.Context, f integrand, a, b float64, e float64, rule KronrodRule,
+	limit int, opts Options) (Result, error) {
+	table, ok := kronrodTables[rule]
+	if !ok {
+		panic("integral: unknown Gauss-Kronrod rule")
+	}
+
+	points := kronrodPoints(a, b, table)
+	value, err := kronrodValues(evaluatePoints(f, len(points), func(i int) float64 { return points[i] },
+		opts.workers()), a, b, table)
+	evals := len(points)
+	intervals := []kronrodInterval{{a: a, b: b, value: value, err: err}}
+	result := Result{Value: value, Error: err, Evaluations: evals, Intervals: 1}
+	opts.observe(observer.Step{H: math.Abs(b - a), Estimate: result.Value, Error: result.Error, N: 1})
+
+	// Суммы по подотрезкам обновляются при каждом делении, а не пересчитываются заново
+	valueSum, errSum := tools.NewAccumulator(opts.Summation), tools.NewAccumulator(opts.Summation)
+	valueSum.Add(value)
+	errSum.Add(err)
+
+	// Последовательность приближений для экстраполяции и последние результаты экстраполяции
+	var sequence, extrapolated []float64
+	maxDepth := 0
+	extValue, extErr := 0.0, math.Inf(1)
+
+	var ctxErr error
+	stalled, exhausted := false, false
+	for len(intervals) < limit && result.Error > e && extErr > e {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		if exhausted = opts.exceeds(result.Evaluations, 2*len(points)); exhausted {
+			break
+		}
+
+		// Подотрезок с наибольшей оценкой погрешности
+		worst := 0
+		for i := range intervals {
+			if intervals[i].err > intervals[worst].err {
+				worst = i
+			}
+		}
+
+		iv := intervals[worst]
+		mid := (iv.a + iv.b) / 2
+		if mid <= math.Min(iv.a, iv.b) || mid >= math.Max(iv.a, iv.b) {
+			// Отрезок нельзя делить дальше в арифметике с плавающей точкой
+			stalled = true
+			break
+		}
+
+		// Узлы обеих половин вычисляются одним пакетом
+		points := append(kronrodPoints(iv.a, mid, table), kronrodPoints(mid, iv.b, table)...)
+		values := evaluatePoints(f, len(points), func(i int) float64 { return points[i] }, opts.workers())
+		k := len(points) / 2
+		v1, e1 := kronrodValues(values[:k], iv.a, mid, table)
+		v2, e2 := kronrodValues(values[k:], mid, iv.b, table)
+		result.Evaluations += len(points)
+
+		intervals[worst] = kronrodInterval{a: iv.a, b: mid, value: v1, err: e1, depth: iv.depth + 1}
+		intervals = append(intervals, kronrodInterval{a: mid, b: iv.b, value: v2, err: e2, depth: iv.depth + 1})
+
+		valueSum.Add(v1)
+		valueSum.Add(v2)
+		valueSum.Add(-iv.value)
+		errSum.Add(e1)
+		errSum.Add(e2)
+		errSum.Add(-iv.err)
+		result.Value, result.Error = valueSum.Sum(), math.Max(errSum.Sum(), 0)
+		result.Intervals = len(intervals)
+		opts.observe(observer.Step{H: math.Abs(mid - iv.a), Estimate: result.Value, Error: result.Error,
+			N: result.Intervals})
+
+		// При каждом новом уровне дробления добавляем сумму в последовательность для экстраполяции
+		if iv.depth+1 > maxDepth {
+			maxDepth = iv.depth + 1
+			sequence = append(sequence, result.Value)
+			if len(sequence) > wynnMaxLength {
+				sequence = sequence[1:]
+			}
+
+			if len(sequence) >= 3 {
+				extrapolated = append(extrapolated, wynnEpsilon(sequence))
+				if k := len(extrapolated); k >= 3 {
+					extValue = extrapolated[k-1]
+					extErr = math.Abs(extValue-extrapolated[k-2]) + math.Abs(extValue-extrapolated[k-3]) +
+						5*machEps*math.Abs(extValue)
+				}
+			}
+		}
+	}
+
+	// Итоговые суммы вычисляются заново, чтобы накопленные при делении ошибки округления не влияли на результат
+	values, errs := make([]float64, len(intervals)), make([]float64, len(intervals))
+	for i := range intervals {
+		values[i], errs[i] = intervals[i].value, intervals[i].err
+	}
+	result.Value, result.Error = tools.Sum(values, opts.Summation), tools.Sum(errs, opts.Summation)
+
+	if extErr < result.Error {
+		result.Value, result.Error = extValue, extErr
+	}
+
+	if ctxErr != nil {
+		return result, ctxErr
+	}
+	if result.Error > e && exhausted {
+		return result, ErrEvalBudget
+	}
+	if result.Error > e && stalled {
+		return result, ErrRoundoff
+	}
+	if result.Error > e {
+		return result, ErrIntervalLimit
+	}
+
+	return result, nil
+}
@@ -0,0 +1,277 @@
+package integral
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"sync/atomic"
+)
+
+const (
+	monteCarloChunk = 4096 // количество случайных точек в одной порции, обрабатываемой одним потоком
+)
+
+// pcgSource источник случайных чисел math/rand на основе генератора PCG из math/rand/v2
+type pcgSource struct {
+	*randv2.PCG
+}
+
+// Int63 возвращает неотрицательное 63-битное случайное число
+func (s pcgSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed заново инициализирует генератор
+func (s pcgSource) Seed(seed int64) {
+	s.PCG.Seed(uint64(seed), 0)
+}
+
+// newStream возвращает генератор порции (ячейки, повторения) i. seed и i задают два слова состояния PCG,
+// поэтому потоки разных порций и соседних seed независимы (при seed + i совпадали бы, например, порция 1
+// при seed = 0 и порция 0 при seed = 1)
+func newStream(seed int64, i int) *rand.Rand {
+	return rand.New(pcgSource{randv2.NewPCG(uint64(seed), uint64(i))})
+}
+
+// sampleStats статистика выборки: объем, среднее и сумма квадратов отклонений от среднего
+type sampleStats struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+// add добавляет значение y к выборке (алгоритм Уэлфорда)
+func (s *sampleStats) add(y float64) {
+	s.n++
+	delta := y - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (y - s.mean)
+}
+
+// merge объединяет статистику двух выборок (формула Чана)
+func (s *sampleStats) merge(o sampleStats) {
+	if o.n == 0 {
+		return
+	}
+	n := s.n + o.n
+	delta := o.mean - s.mean
+	s.mean += delta * float64(o.n) / float64(n)
+	s.m2 += o.m2 + delta*delta*float64(s.n)*float64(o.n)/float64(n)
+	s.n = n
+}
+
+// variance возвращает несмещенную оценку дисперсии выборки
+func (s sampleStats) variance() float64 {
+	if s.n < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.n-1)
+}
+
+// standardError возвращает стандартную ошибку среднего выборки (+Inf, если в выборке меньше двух значений)
+func (s sampleStats) standardError() float64 {
+	if s.n < 2 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(s.variance() / float64(s.n))
+}
+
+// runParallel выполняет задания с номерами 0..count-1 в workers потоках (см. parallelFor)
+// Перед каждым заданием проверяется ctx: после его отмены оставшиеся задания пропускаются с пустой статистикой,
+// и возвращается ctx.Err(). Статистики возвращаются в порядке номеров заданий, поэтому итог не зависит от числа потоков
+func runParallel(ctx context.Context, count, workers int, work func(i int) sampleStats) ([]sampleStats, error) {
+	results := make([]sampleStats, count)
+	var skipped atomic.Bool
+	parallelFor(count, workers, func(i int) {
+		if ctx.Err() != nil {
+			skipped.Store(true)
+			return
+		}
+		results[i] = work(i)
+	})
+
+	if skipped.Load() {
+		return results, ctx.Err()
+	}
+	return results, nil
+}
+
+// boxVolume проверяет границы параллелепипеда [a, b] и возвращает его объем
+func boxVolume(a, b []float64) float64 {
+	if len(a) == 0 || len(b) != len(a) {
+		panic("integral: bounds must have the same positive length")
+	}
+
+	volume := 1.0
+	for k := range a {
+		volume *= b[k] - a[k]
+	}
+
+	return volume
+}
+
+// IntegrateMonteCarlo вычисляет приближенное значение интеграла функции f по параллелепипеду [a, b]
+// методом Монте-Карло по n равномерно распределенным случайным точкам
+// Точки разбиваются на порции, каждая порция использует собственный генератор newStream(seed, номер порции),
+// поэтому результат воспроизводим при одинаковом seed и не зависит от числа потоков workers (<= 0 - по числу процессоров)
+// При workers != 1 функция f вызывается из нескольких горутин одновременно и должна быть потокобезопасной
+// Возвращает результат, Error - стандартная ошибка оценки
+func IntegrateMonteCarlo(f integrandND, a, b []float64, n int, seed int64, workers int) Result {
+	result, _ := IntegrateMonteCarloContext(context.Background(), f, a, b, n, seed, workers)
+
+	return result
+}
+
+// IntegrateMonteCarloContext работает как IntegrateMonteCarlo, но проверяет ctx перед каждой порцией точек
+// При отмене ctx или истечении его срока возвращает оценку по обработанным порциям и ctx.Err()
+func IntegrateMonteCarloContext(ctx context.Context, f integrandND, a, b []float64, n int, seed int64,
+	workers int) (Result, error) {
+	volume := boxVolume(a, b)
+	if n < 2 {
+		panic("integral: Monte Carlo integration requires at least two points")
+	}
+
+	chunks := (n + monteCarloChunk - 1) / monteCarloChunk
+	parts, err := runParallel(ctx, chunks, workers, func(i int) sampleStats {
+		r := newStream(seed, i)
+		x := make([]float64, len(a))
+		var s sampleStats
+		for j := i * monteCarloChunk; j < n && j < (i+1)*monteCarloChunk; j++ {
+			for k := range x {
+				x[k] = a[k] + (b[k]-a[k])*r.Float64()
+			}
+			s.add(f(x))
+		}
+		return s
+	})
+
+	var total sampleStats
+	for _, s := range parts {
+		total.merge(s)
+	}
+
+	return Result{
+		Value:       volume * total.mean,
+		Error:       math.Abs(volume) * total.standardError(),
+		Evaluations: total.n,
+	}, err
+}
+
+// IntegrateStratified вычисляет приближенное значение интеграла функции f по параллелепипеду [a, b]
+// методом Монте-Карло с расслоением: каждая переменная делится на strata равных частей, и в каждой из strata^d
+// ячеек берется samples >= 2 случайных точек. Ячейка c использует генератор newStream(seed, c)
+// Параметр workers имеет тот же смысл, что и в IntegrateMonteCarlo
+// Возвращает результат, Error - стандартная ошибка оценки, Intervals - количество ячеек
+func IntegrateStratified(f integrandND, a, b []float64, strata, samples int, seed int64, workers int) Result {
+	result, _ := IntegrateStratifiedContext(context.Background(), f, a, b, strata, samples, seed, workers)
+
+	return result
+}
+
+// IntegrateStratifiedContext работает как IntegrateStratified, но проверяет ctx перед каждой ячейкой
+// При отмене ctx или истечении его срока возвращает интеграл по объединению обработанных ячеек, их количество
+// в Intervals и ctx.Err()
+func IntegrateStratifiedContext(ctx context.Context, f integrandND, a, b []float64, strata, samples int, seed int64,
+	workers int) (Result, error) {
+	volume := boxVolume(a, b)
+	if strata < 1 || samples < 2 {
+		panic("integral: stratified sampling requires at least one stratum and two samples per stratum")
+	}
+
+	d := len(a)
+	cells := 1
+	for k := 0; k < d; k++ {
+		cells *= strata
+	}
+
+	parts, err := runParallel(ctx, cells, workers, func(c int) sampleStats {
+		r := newStream(seed, c)
+
+		// Номер ячейки - число в системе счисления с основанием strata, цифры - номера слоев по переменным
+		lo := make([]float64, d)
+		for k, rest := 0, c; k < d; k++ {
+			lo[k] = float64(rest % strata)
+			rest /= strata
+		}
+
+		x := make([]float64, d)
+		var s sampleStats
+		for j := 0; j < samples; j++ {
+			for k := range x {
+				x[k] = a[k] + (b[k]-a[k])*(lo[k]+r.Float64())/float64(strata)
+			}
+			s.add(f(x))
+		}
+		return s
+	})
+
+	cellVolume := volume / float64(cells)
+	value, variance := 0.0, 0.0
+	done := 0
+	for _, s := range parts {
+		if s.n == 0 {
+			continue
+		}
+		value += cellVolume * s.mean
+		variance += cellVolume * cellVolume * s.variance() / float64(samples)
+		done++
+	}
+
+	return Result{
+		Value:       value,
+		Error:       math.Sqrt(variance),
+		Evaluations: done * samples,
+		Intervals:   done,
+	}, err
+}
+
+// IntegrateImportance вычисляет приближенное значение интеграла функции f d переменных методом Монте-Карло
+// с выборкой по значимости: sample заполняет x случайной точкой с плотностью распределения p и возвращает p(x),
+// интеграл оценивается средним f(x) / p(x) по n точкам. Область интегрирования - носитель плотности p,
+// поэтому метод пригоден и для бесконечных областей
+// Параметры seed и workers имеют тот же смысл, что и в IntegrateMonteCarlo; sample получает генератор своей порции
+// Возвращает результат, Error - стандартная ошибка оценки
+func IntegrateImportance(f integrandND, d int, sample func(r *rand.Rand, x []float64) float64, n int, seed int64, workers int) Result {
+	result, _ := IntegrateImportanceContext(context.Background(), f, d, sample, n, seed, workers)
+
+	return result
+}
+
+// IntegrateImportanceContext работает как IntegrateImportance, но проверяет ctx перед каждой порцией точек
+// При отмене ctx или истечении его срока возвращает оценку по обработанным порциям и ctx.Err()
+func IntegrateImportanceContext(ctx context.Context, f integrandND, d int, sample func(r *rand.Rand, x []float64) float64,
+	n int, seed int64, workers int) (Result, error) {
+	if d < 1 {
+		panic("integral: dimension must be positive")
+	}
+	if n < 2 {
+		panic("integral: Monte Carlo integration requires at least two points")
+	}
+
+	chunks := (n + monteCarloChunk - 1) / monteCarloChunk
+	parts, err := runParallel(ctx, chunks, workers, func(i int) sampleStats {
+		r := newStream(seed, i)
+		x := make([]float64, d)
+		var s sampleStats
+		for j := i * monteCarloChunk; j < n && j < (i+1)*monteCarloChunk; j++ {
+			p := sample(r, x)
+			if p <= 0 {
+				panic("integral: sampling density must be positive at sampled points")
+			}
+			s.add(f(x) / p)
+		}
+		return s
+	})
+
+	var total sampleStats
+	for _, s := range parts {
+		total.merge(s)
+	}
+
+	return Result{
+		Value:       total.mean,
+		Error:       total.standardError(),
+		Evaluations: total.n,
+	}, err
+}
@@ -0,0 +1,39 @@
+package integral
+
+import (
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// Options задает необязательные параметры адаптивных методов интегрирования (функций с суффиксом WithOptions).
+// Нулевое значение соответствует параметрам по умолчанию: f вычисляется последовательно в вызывающем потоке,
+// значения складываются методом Ноймайера, число вычислений f не ограничено, наблюдатель не используется.
+// При Workers != 0 и 1 функция f должна допускать одновременные вызовы; значения всегда складываются в фиксированном
+// порядке, поэтому результат побитово не зависит от числа потоков.
+type Options struct {
+	Workers   int               // Количество потоков для вычисления f (0 и 1 - один поток, < 0 - по числу процессоров).
+	Summation tools.Summation   // Способ суммирования значений f.
+	MaxEvals  int               // Максимальное количество вычислений f (0 - без ограничения).
+	Observer  observer.Observer // Наблюдатель, получающий оценки на каждом шаге метода.
+}
+
+// workers возвращает количество потоков в формате parallelFor (<= 0 - по числу процессоров)
+func (o Options) workers() int {
+	if o.Workers == 0 {
+		return 1
+	}
+
+	return o.Workers
+}
+
+// exceeds проверяет, превысит ли бюджет вычисление f еще в n точках после evals вычислений
+func (o Options) exceeds(evals, n int) bool {
+	return o.MaxEvals > 0 && evals+n > o.MaxEvals
+}
+
+// observe передает шаг наблюдателю, если он задан
+func (o Options) observe(step observer.Step) {
+	if o.Observer != nil {
+		o.Observer.Observe(step)
+	}
+}
@@ -0,0 +1,248 @@
+package integral
+
+import (
+	"context"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// Oscillator выбирает осциллирующий множитель подынтегральной функции f(x)·sin(ωx) или f(x)·cos(ωx)
+type Oscillator int
+
+const (
+	Sine   Oscillator = iota // множитель sin(ωx)
+	Cosine                   // множитель cos(ωx)
+)
+
+const (
+	filonSeriesThreshold = 1.0 / 6 // при θ = ωh меньше порога коэффициенты Филона вычисляются по рядам Тейлора
+	filonMaxPoints       = 1 << 20 // максимальное количество отрезков в адаптивной формуле Филона
+)
+
+// filonCoefficients вычисляет коэффициенты α, β, γ формулы Филона для θ = ωh
+// При малых θ явные формулы теряют точность из-за вычитания близких чисел, поэтому используются ряды
+func filonCoefficients(theta float64) (alpha, beta, gamma float64) {
+	if math.Abs(theta) < filonSeriesThreshold {
+		t2 := theta * theta
+		t3 := t2 * theta
+		alpha = t3 * (2.0/45 - t2*(2.0/315-t2*2.0/4725))
+		beta = 2.0/3 + t2*(2.0/15-t2*(4.0/105-t2*2.0/567))
+		gamma = 4.0/3 - t2*(2.0/15-t2*(1.0/210-t2/11340))
+		return alpha, beta, gamma
+	}
+
+	sin, cos := math.Sincos(theta)
+	t3 := theta * theta * theta
+	alpha = (theta*theta + theta*sin*cos - 2*sin*sin) / t3
+	beta = 2 * (theta*(1+cos*cos) - 2*sin*cos) / t3
+	gamma = 4 * (sin - theta*cos) / t3
+	return alpha, beta, gamma
+}
+
+// filonSums вычисляет суммы значений f(x)·trig(ωx) по четным и нечетным узлам сетки с n отрезками
+// (концы отрезка входят в сумму по четным узлам с множителем 1/2), слагаемые складываются способом summation
+func filonSums(values []float64, a, h, omega float64, kind Oscillator, summation tools.Summation) (even, odd float64) {
+	n := len(values) - 1
+	evenTerms := make([]float64, 0, n/2+1)
+	oddTerms := make([]float64, 0, n/2)
+	for i, v := range values {
+		x := a + float64(i)*h
+		var trig float64
+		if kind == Sine {
+			trig = math.Sin(omega * x)
+		} else {
+			trig = math.Cos(omega * x)
+		}
+
+		switch {
+		case i == 0 || i == n:
+			evenTerms = append(evenTerms, v*trig/2)
+		case i%2 == 0:
+			evenTerms = append(evenTerms, v*trig)
+		default:
+			oddTerms = append(oddTerms, v*trig)
+		}
+	}
+
+	return tools.Sum(evenTerms, summation), tools.Sum(oddTerms, summation)
+}
+
+// filonRule применяет формулу Филона к значениям функции в n+1 равноотстоящих узлах отрезка [a, b] (n четно)
+// Суммы значений складываются способом summation
+func filonRule(values []float64, a, b, omega float64, kind Oscillator, summation tools.Summation) float64 {
+	n := len(values) - 1
+	h := (b - a) / float64(n)
+	alpha, beta, gamma := filonCoefficients(omega * h)
+	even, odd := filonSums(values, a, h, omega, kind, summation)
+
+	fa, fb := values[0], values[n]
+	if kind == Sine {
+		return h * (alpha*(fa*math.Cos(omega*a)-fb*math.Cos(omega*b)) + beta*even + gamma*odd)
+	}
+
+	return h * (alpha*(fb*math.Sin(omega*b)-fa*math.Sin(omega*a)) + beta*even + gamma*odd)
+}
+
+// IntegrateFilon вычисляет приближенное значение интеграла функции f(x)·sin(ωx) или f(x)·cos(ωx) от a до b
+// по формуле Филона с n (четным) отрезками: f приближается параболами на парах отрезков, а произведение
+// парабол на осциллирующий множитель интегрируется точно. Поэтому n определяется гладкостью f, а не частотой ω
+func IntegrateFilon(f integrand, a, b, omega float64, kind Oscillator, n int) float64 {
+	if n < 2 || n%2 != 0 {
+		panic("integral: number of subintervals for Filon's rule must be even and positive")
+	}
+
+	values := make([]float64, n+1)
+	h := (b - a) / float64(n)
+	for i := range values {
+		values[i] = f(a + float64(i)*h)
+	}
+
+	return filonRule(values, a, b, omega, kind, tools.NeumaierSum)
+}
+
+// IntegrateFilonAdaptive вычисляет приближенное значение интеграла функции f(x)·sin(ωx) или f(x)·cos(ωx) от a до b
+// с заданной точностью e по формуле Филона, удваивая число отрезков. Функция вычисляется только в новых узлах,
+// погрешность оценивается разностью соседних приближений. Если шаг кратен периоду множителя, соседние приближения
+// могут случайно совпасть, поэтому точность считается достигнутой, когда две разности подряд не превосходят e
+// Возвращает результат и ErrNotConverged, если точность не достигнута
+func IntegrateFilonAdaptive(f integrand, a, b, omega float64, kind Oscillator, e float64) (Result, error) {
+	return IntegrateFilonAdaptiveContext(context.Background(), f, a, b, omega, kind, e)
+}
+
+// IntegrateFilonAdaptiveContext работает как IntegrateFilonAdaptive, но проверяет ctx перед каждым удвоением числа
+// отрезков. При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateFilonAdaptiveContext(ctx context.Context, f integrand, a, b, omega float64, kind Oscillator,
+	e float64) (Result, error) {
+	return IntegrateFilonAdaptiveWithOptions(ctx, f, a, b, omega, kind, e, Options{})
+}
+
+// IntegrateFilonAdaptiveWithOptions работает как IntegrateFilonAdaptiveContext с параметрами opts (см. Options)
+// Новые узлы каждого уровня вычисляются одним пакетом в opts.Workers потоках, значения суммируются в порядке узлов
+// opts.Observer получает шаг, приближение, разность соседних приближений и количество отрезков каждого уровня. Если следующий уровень превысил бы opts.MaxEvals вычислений f, возвращает ErrEvalBudget
+func IntegrateFilonAdaptiveWithOptions(ctx context.Context, f integrand, a, b, omega float64, kind Oscillator,
+	e float64, opts Options) (Result, error) {
+	n := 2
+	values := evaluatePoints(f, n+1, func(i int) float64 {
+		return a + float64(i)*(b-a)/float64(n)
+	}, opts.workers())
+	result := Result{Value: filonRule(values, a, b, omega, kind, opts.Summation), Error: math.Inf(1), Evaluations: 3,
+		Intervals: n}
+
+	var prevError float64
+	for n < filonMaxPoints {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if opts.exceeds(result.Evaluations, n) {
+			return result, ErrEvalBudget
+		}
+
+		n *= 2
+		h := (b - a) / float64(n)
+		odd := evaluatePoints(f, n/2, func(j int) float64 {
+			return a + float64(2*j+1)*h
+		}, opts.workers())
+		result.Evaluations += n / 2
+		refined := make([]float64, n+1)
+		for i := range refined {
+			if i%2 == 0 {
+				refined[i] = values[i/2]
+			} else {
+				refined[i] = odd[i/2]
+			}
+		}
+		values = refined
+
+		value := filonRule(values, a, b, omega, kind, opts.Summation)
+		prevError, result.Error = result.Error, math.Abs(value-result.Value)
+		result.Value = value
+		result.Intervals = n
+		opts.observe(observer.Step{H: h, Estimate: result.Value, Error: result.Error, N: n})
+
+		if result.Error <= e && prevError <= e {
+			return result, nil
+		}
+	}
+
+	return result, ErrNotConverged
+}
+
+// IntegrateLevin вычисляет приближенные значения интегралов ∫ f(x)·cos(ωx) dx и ∫ f(x)·sin(ωx) dx от a до b
+// методом коллокации Левина: ищется функция p(x) = u(x) + i·v(x), для которой (p(x)·e^(iωx))' = f(x)·e^(iωx),
+// т.е. p' + iωp = f, тогда интеграл равен p(b)·e^(iωb) - p(a)·e^(iωa). u и v ищутся в виде разложений
+// по n многочленам Чебышева, уравнение выполняется в n узлах Чебышева-Лобатто. Точность растет с частотой ω,
+// поэтому метод предназначен для больших ω (при ω → 0 система вырождается)
+// Возвращает интегралы с косинусом и синусом или ошибку, если система коллокации вырождена
+func IntegrateLevin(f integrand, a, b, omega float64, n int) (float64, float64, error) {
+	if n < 2 {
+		panic("integral: Levin collocation requires at least two points")
+	}
+
+	// Неизвестные: коэффициенты c (для u) и d (для v); уравнения u' - ωv = f и v' + ωu = 0 в узлах коллокации
+	A := make([][]float64, 2*n)
+	B := make([]float64, 2*n)
+	scale := 2 / (b - a)
+	for j := 0; j < n; j++ {
+		t := -math.Cos(math.Pi * float64(j) / float64(n-1))
+		T, dT := chebyshevBasis(t, n)
+
+		A[j] = make([]float64, 2*n)
+		A[n+j] = make([]float64, 2*n)
+		for k := 0; k < n; k++ {
+			A[j][k] = scale * dT[k]
+			A[j][n+k] = -omega * T[k]
+			A[n+j][k] = omega * T[k]
+			A[n+j][n+k] = scale * dT[k]
+		}
+		B[j] = f((a+b)/2 + (b-a)/2*t)
+	}
+
+	coeffs, err := equations.GaussPivotMethod(A, B)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Значения u и v на концах: T_k(-1) = (-1)^k, T_k(1) = 1
+	var ua, va, ub, vb float64
+	sign := 1.0
+	for k := 0; k < n; k++ {
+		ua += sign * coeffs[k]
+		va += sign * coeffs[n+k]
+		ub += coeffs[k]
+		vb += coeffs[n+k]
+		sign = -sign
+	}
+
+	sinA, cosA := math.Sincos(omega * a)
+	sinB, cosB := math.Sincos(omega * b)
+	cosine := (ub*cosB - vb*sinB) - (ua*cosA - va*sinA)
+	sine := (ub*sinB + vb*cosB) - (ua*sinA + va*cosA)
+
+	return cosine, sine, nil
+}
+
+// chebyshevBasis вычисляет значения многочленов Чебышева T_0..T_(n-1) и их производных в точке t ∈ [-1, 1]
+// Производные вычисляются через многочлены второго рода: T_k' = k·U_(k-1)
+func chebyshevBasis(t float64, n int) ([]float64, []float64) {
+	T := make([]float64, n)
+	dT := make([]float64, n)
+
+	T[0] = 1
+	if n > 1 {
+		T[1] = t
+	}
+	for k := 2; k < n; k++ {
+		T[k] = 2*t*T[k-1] - T[k-2]
+	}
+
+	uPrev, u := 0.0, 1.0 // U_(k-2), U_(k-1)
+	for k := 1; k < n; k++ {
+		dT[k] = float64(k) * u
+		uPrev, u = u, 2*t*u-uPrev
+	}
+
+	return T, dT
+}
@@ -0,0 +1,75 @@
+package integral
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelFor выполняет задания с номерами 0..count-1 в workers потоках (workers <= 0 - по числу процессоров)
+// Потоки берут задания по очереди, поэтому порядок выполнения не определен: задание должно записывать результат
+// по своему номеру, а вызывающая функция - обрабатывать результаты последовательно в порядке номеров.
+// Тогда итог побитово не зависит от числа потоков
+func parallelFor(count, workers int, work func(i int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > count {
+		workers = count
+	}
+
+	if workers <= 1 {
+		for i := 0; i < count; i++ {
+			work(i)
+		}
+		return
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < count; i = int(next.Add(1) - 1) {
+				work(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// evaluatePoints вычисляет f в n точках x(0), ..., x(n-1) в workers потоках (см. parallelFor)
+// Значения возвращаются в порядке номеров точек
+func evaluatePoints(f integrand, n int, x func(i int) float64, workers int) []float64 {
+	values := make([]float64, n)
+	parallelFor(n, workers, func(i int) {
+		values[i] = f(x(i))
+	})
+
+	return values
+}
+
+// evaluateVectors вычисляет вектор-функцию f в n точках x(0), ..., x(n-1) в workers потоках (см. parallelFor)
+// Значения копируются и проверяются на совпадение размерности с d (d < 0 - с размерностью первого значения,
+// которая должна быть положительной). Возвращает значения в порядке номеров точек
+func evaluateVectors(f integrandVector, n int, x func(i int) float64, d, workers int) [][]float64 {
+	values := make([][]float64, n)
+	parallelFor(n, workers, func(i int) {
+		values[i] = append([]float64(nil), f(x(i))...)
+	})
+
+	if d < 0 && n > 0 {
+		d = len(values[0])
+		if d == 0 {
+			panic("integral: vector integrand must return a non-empty slice")
+		}
+	}
+	for _, v := range values {
+		if len(v) != d {
+			panic("integral: vector integrand must return slices of the same length")
+		}
+	}
+
+	return values
+}
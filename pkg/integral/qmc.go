@@ -0,0 +1,212 @@
+package integral
+
+import (
+	"context"
+	"math/bits"
+)
+
+// QuasiRandom выбирает последовательность с низкой невязкой для метода квази-Монте-Карло
+type QuasiRandom int
+
+const (
+	Sobol  QuasiRandom = iota // последовательность Соболя (не более 21 переменной)
+	Halton                    // последовательность Холтона
+)
+
+// sobolDirections параметры направляющих чисел Соболя для переменных 2..21 (Джо и Куо):
+// степень s и коэффициенты a примитивного многочлена, начальные числа m
+var sobolDirections = []struct {
+	s, a int
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+	{6, 19, []uint32{1, 1, 1, 15, 7, 5}},
+	{6, 22, []uint32{1, 3, 1, 15, 13, 25}},
+	{6, 25, []uint32{1, 1, 5, 5, 19, 61}},
+	{7, 1, []uint32{1, 3, 7, 11, 23, 15, 103}},
+	{7, 4, []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
+
+// SobolSequence генерирует точки последовательности Соболя в [0, 1)^d (не более 2^32 точек)
+type SobolSequence struct {
+	v     [][32]uint32 // направляющие числа
+	x     []uint32
+	index uint32
+}
+
+// NewSobolSequence создает последовательность Соболя размерности d, 1 <= d <= 21
+func NewSobolSequence(d int) *SobolSequence {
+	if d < 1 || d > len(sobolDirections)+1 {
+		panic("integral: Sobol sequence dimension is out of range")
+	}
+
+	seq := &SobolSequence{v: make([][32]uint32, d), x: make([]uint32, d)}
+	for j := 0; j < 32; j++ {
+		seq.v[0][j] = 1 << uint(31-j)
+	}
+
+	for k := 1; k < d; k++ {
+		dir := sobolDirections[k-1]
+		v := &seq.v[k]
+		for j := 0; j < 32; j++ {
+			if j < dir.s {
+				v[j] = dir.m[j] << uint(31-j)
+				continue
+			}
+
+			// Рекуррентное соотношение по примитивному многочлену степени s
+			v[j] = v[j-dir.s] ^ (v[j-dir.s] >> uint(dir.s))
+			for l := 1; l < dir.s; l++ {
+				if (dir.a>>uint(dir.s-1-l))&1 != 0 {
+					v[j] ^= v[j-l]
+				}
+			}
+		}
+	}
+
+	return seq
+}
+
+// Next записывает в x очередную точку последовательности, начиная с нулевой
+func (seq *SobolSequence) Next(x []float64) {
+	for k := range seq.x {
+		x[k] = float64(seq.x[k]) / (1 << 32)
+	}
+
+	// Код Грея: следующая точка отличается одним направляющим числом с номером младшего нулевого бита индекса
+	c := bits.TrailingZeros32(^seq.index)
+	for k := range seq.x {
+		seq.x[k] ^= seq.v[k][c]
+	}
+	seq.index++
+}
+
+// HaltonSequence генерирует точки последовательности Холтона в [0, 1)^d
+// Координата k - обращение номера точки в системе счисления с основанием, равным k-му простому числу
+type HaltonSequence struct {
+	bases []int
+	index int
+}
+
+// NewHaltonSequence создает последовательность Холтона размерности d >= 1
+func NewHaltonSequence(d int) *HaltonSequence {
+	if d < 1 {
+		panic("integral: dimension must be positive")
+	}
+
+	bases := make([]int, 0, d)
+	for p := 2; len(bases) < d; p++ {
+		prime := true
+		for _, q := range bases {
+			if q*q > p {
+				break
+			}
+			if p%q == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			bases = append(bases, p)
+		}
+	}
+
+	return &HaltonSequence{bases: bases}
+}
+
+// Next записывает в x очередную точку последовательности, начиная с нулевой
+func (seq *HaltonSequence) Next(x []float64) {
+	for k, base := range seq.bases {
+		value, scale := 0.0, 1.0
+		for i := seq.index; i > 0; i /= base {
+			scale /= float64(base)
+			value += float64(i%base) * scale
+		}
+		x[k] = value
+	}
+	seq.index++
+}
+
+// IntegrateQuasiMonteCarlo вычисляет приближенное значение интеграла функции f по параллелепипеду [a, b]
+// рандомизированным методом квази-Монте-Карло: первые n точек последовательности kind сдвигаются по модулю 1
+// на случайный вектор (сдвиг Кренли-Паттерсона), и оценка повторяется для replicas >= 2 независимых сдвигов
+// Сдвиг повторения r получается из генератора newStream(seed, r); повторения вычисляются
+// в workers потоках (<= 0 - по числу процессоров), при workers != 1 функция f должна быть потокобезопасной
+// Возвращает среднее по повторениям, Error - стандартная ошибка по разбросу повторений
+func IntegrateQuasiMonteCarlo(f integrandND, a, b []float64, n int, kind QuasiRandom, replicas int, seed int64, workers int) Result {
+	result, _ := IntegrateQuasiMonteCarloContext(context.Background(), f, a, b, n, kind, replicas, seed, workers)
+
+	return result
+}
+
+// IntegrateQuasiMonteCarloContext работает как IntegrateQuasiMonteCarlo, но проверяет ctx перед каждым повторением
+// При отмене ctx или истечении его срока возвращает среднее по завершенным повторениям и ctx.Err()
+// (Error = +Inf, если завершено меньше двух повторений)
+func IntegrateQuasiMonteCarloContext(ctx context.Context, f integrandND, a, b []float64, n int, kind QuasiRandom,
+	replicas int, seed int64, workers int) (Result, error) {
+	volume := boxVolume(a, b)
+	if n < 1 || replicas < 2 {
+		panic("integral: quasi-Monte Carlo integration requires at least one point and two replicas")
+	}
+
+	d := len(a)
+	parts, err := runParallel(ctx, replicas, workers, func(i int) sampleStats {
+		var next func(x []float64)
+		switch kind {
+		case Sobol:
+			next = NewSobolSequence(d).Next
+		case Halton:
+			next = NewHaltonSequence(d).Next
+		default:
+			panic("integral: unknown quasi-random sequence")
+		}
+
+		r := newStream(seed, i)
+		shift := make([]float64, d)
+		for k := range shift {
+			shift[k] = r.Float64()
+		}
+
+		u := make([]float64, d)
+		x := make([]float64, d)
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			next(u)
+			for k := range x {
+				t := u[k] + shift[k]
+				if t >= 1 {
+					t--
+				}
+				x[k] = a[k] + (b[k]-a[k])*t
+			}
+			sum += f(x)
+		}
+
+		return sampleStats{n: 1, mean: volume * sum / float64(n)}
+	})
+
+	var total sampleStats
+	for _, s := range parts {
+		total.merge(s)
+	}
+
+	return Result{
+		Value:       total.mean,
+		Error:       total.standardError(),
+		Evaluations: n * total.n,
+	}, err
+}
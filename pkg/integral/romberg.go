@@ -0,0 +1,85 @@
+package integral
+
+import (
+	"context"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+)
+
+const (
+	rombergMaxLevels = 30 // максимальное количество уровней таблицы Ромберга (до 2^29 отрезков)
+)
+
+// IntegrateRomberg вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
+// методом Ромберга: последовательность формул трапеций с шагом h, h/2, h/4, ... экстраполируется по Ричардсону
+// При переходе на следующий уровень функция вычисляется только в новых точках
+// Возвращает значение интеграла и таблицу Ромберга R, где R[k][0] - формула трапеций с 2^k отрезками,
+// R[k][j] - j-я экстраполяция порядка 2j+2
+func IntegrateRomberg(f integrand, a, b float64, e float64, obs observer.Observer) (float64, [][]float64) {
+	result, tableau, _ := romberg(context.Background(), f, a, b, e, Options{Observer: obs})
+
+	return result.Value, tableau
+}
+
+// IntegrateRombergContext работает как IntegrateRomberg, но проверяет ctx перед вычислением каждой строки таблицы
+// Возвращает результат, в котором погрешность оценивается разностью диагональных элементов двух последних строк
+// таблицы, а Intervals - число отрезков последней формулы трапеций
+// При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateRombergContext(ctx context.Context, f integrand, a, b float64, e float64,
+	obs observer.Observer) (Result, error) {
+	return IntegrateRombergWithOptions(ctx, f, a, b, e, Options{Observer: obs})
+}
+
+// IntegrateRombergWithOptions работает как IntegrateRombergContext с параметрами opts (см. Options)
+// Новые точки каждой строки таблицы вычисляются одним пакетом в opts.Workers потоках и суммируются в порядке
+// возрастания x. Если следующая строка превысила бы opts.MaxEvals вычислений f, возвращает ErrEvalBudget
+func IntegrateRombergWithOptions(ctx context.Context, f integrand, a, b float64, e float64,
+	opts Options) (Result, error) {
+	result, _, err := romberg(ctx, f, a, b, e, opts)
+
+	return result, err
+}
+
+// romberg реализует IntegrateRomberg и IntegrateRombergWithOptions
+// Возвращает результат, построенную часть таблицы Ромберга и ошибку
+func romberg(ctx context.Context, f integrand, a, b float64, e float64,
+	opts Options) (Result, [][]float64, error) {
+	n := 1
+	tableau := [][]float64{{trapezoidalRule(f, a, b, n, opts.workers(), opts.Summation)}}
+	evals := n + 1
+	r := math.Inf(1)
+
+	var err error
+	for k := 1; k < rombergMaxLevels; k++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		if opts.exceeds(evals, n) {
+			err = ErrEvalBudget
+			break
+		}
+
+		row := make([]float64, k+1)
+		row[0] = trapezoidalRefine(f, a, b, n, tableau[k-1][0], opts.workers(), opts.Summation)
+		evals += n
+		n *= 2
+
+		// Экстраполяция Ричардсона: R[k][j] = R[k][j-1] + (R[k][j-1] - R[k-1][j-1]) / (4^j - 1)
+		for j := 1; j <= k; j++ {
+			row[j] = row[j-1] + (row[j-1]-tableau[k-1][j-1])/(math.Ldexp(1, 2*j)-1)
+		}
+		tableau = append(tableau, row)
+
+		r = math.Abs(row[k] - tableau[k-1][k-1])
+		opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: row[k], Error: r, N: n})
+
+		if r <= e {
+			break
+		}
+	}
+
+	last := tableau[len(tableau)-1]
+
+	return Result{Value: last[len(last)-1], Error: r, Evaluations: evals, Intervals: n}, tableau, err
+}
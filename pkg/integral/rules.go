@@ -0,0 +1,83 @@
+package integral
+
+import (
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/node"
+)
+
+// QuadratureRule строит узлы и веса квадратурной формулы с n узлами на отрезке [a, b]
+// Возвращает узлы в порядке возрастания X, Y - веса. Этой сигнатуре соответствуют также
+// node.BuildGaussLegendreNodes, node.BuildGaussLobattoNodes и node.BuildGaussRadauNodes
+type QuadratureRule func(a, b float64, n int) []node.Node
+
+// TrapezoidalNodes строит узлы и веса составной формулы трапеций с n >= 2 равноотстоящими узлами на [a, b]
+func TrapezoidalNodes(a, b float64, n int) []node.Node {
+	if n < 2 {
+		panic("integral: trapezoidal rule requires at least two nodes")
+	}
+
+	h := (b - a) / float64(n-1)
+	nodes := make([]node.Node, n)
+	for i := range nodes {
+		nodes[i] = node.Node{X: a + float64(i)*h, Y: h}
+	}
+	nodes[0].Y, nodes[n-1].Y = h/2, h/2
+
+	return nodes
+}
+
+// SimpsonNodes строит узлы и веса составной формулы Симпсона с n равноотстоящими узлами на [a, b]
+// (n нечетно и не меньше 3)
+func SimpsonNodes(a, b float64, n int) []node.Node {
+	if n < 3 || n%2 == 0 {
+		panic("integral: Simpson's rule requires an odd number of nodes, at least three")
+	}
+
+	h := (b - a) / float64(n-1)
+	nodes := make([]node.Node, n)
+	for i := range nodes {
+		w := 2 * h / 3
+		if i%2 == 1 {
+			w = 4 * h / 3
+		}
+		nodes[i] = node.Node{X: a + float64(i)*h, Y: w}
+	}
+	nodes[0].Y, nodes[n-1].Y = h/3, h/3
+
+	return nodes
+}
+
+// ClenshawCurtisNodes строит узлы и веса формулы Кленшоу-Кертиса с n >= 2 узлами на [a, b]
+// (узлы (a+b)/2 - (b-a)/2·cos(kπ/(n-1)), k = 0..n-1, включают концы отрезка)
+func ClenshawCurtisNodes(a, b float64, n int) []node.Node {
+	if n < 2 {
+		panic("integral: Clenshaw-Curtis rule requires at least two nodes")
+	}
+
+	weights := clenshawCurtisWeights(n - 1)
+	nodes := make([]node.Node, n)
+	for k := range nodes {
+		x := -math.Cos(math.Pi * float64(k) / float64(n-1))
+		nodes[k] = node.Node{X: (a+b)/2 + (b-a)/2*x, Y: (b - a) / 2 * weights[k]}
+	}
+
+	return nodes
+}
+
+// FejerNodes строит узлы и веса формулы Фейера первого рода с n >= 1 узлами на [a, b]
+// (узлы (a+b)/2 - (b-a)/2·cos((k+1/2)π/n), k = 0..n-1, концы отрезка не входят)
+func FejerNodes(a, b float64, n int) []node.Node {
+	if n < 1 {
+		panic("integral: number of nodes must be positive")
+	}
+
+	weights := fejerWeights(n)
+	nodes := make([]node.Node, n)
+	for k := range nodes {
+		x := -math.Cos(math.Pi * (float64(k) + 0.5) / float64(n))
+		nodes[k] = node.Node{X: (a+b)/2 + (b-a)/2*x, Y: (b - a) / 2 * weights[k]}
+	}
+
+	return nodes
+}
@@ -0,0 +1,279 @@
+package integral
+
+import (
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/node"
+)
+
+// integrand3D представляет функцию трех переменных, которую необходимо интегрировать
+type integrand3D func(x, y, z float64) float64
+
+// simplexOrbit группа симметричных узлов формулы на симплексе: все различные перестановки барицентрических
+// координат point с одинаковым весом weight (веса нормированы так, что их сумма равна 1)
+type simplexOrbit struct {
+	weight float64
+	point  []float64
+}
+
+// simplexRule формула на симплексе: барицентрические координаты узлов и веса
+type simplexRule struct {
+	points  [][]float64
+	weights []float64
+}
+
+// dunavantTables симметричные формулы Данаванта на треугольнике степеней 1..8
+var dunavantTables = [][]simplexOrbit{
+	{
+		{1, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+	},
+	{
+		{1.0 / 3, []float64{2.0 / 3, 1.0 / 6, 1.0 / 6}},
+	},
+	{
+		{-27.0 / 48, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{25.0 / 48, []float64{0.6, 0.2, 0.2}},
+	},
+	{
+		{0.223381589678011, []float64{0.108103018168070, 0.445948490915965, 0.445948490915965}},
+		{0.109951743655322, []float64{0.816847572980459, 0.091576213509771, 0.091576213509771}},
+	},
+	{
+		{0.225, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{0.132394152788506, []float64{0.059715871789770, 0.470142064105115, 0.470142064105115}},
+		{0.125939180544827, []float64{0.797426985353087, 0.101286507323456, 0.101286507323456}},
+	},
+	{
+		{0.116786275726379, []float64{0.501426509658179, 0.249286745170910, 0.249286745170910}},
+		{0.050844906370207, []float64{0.873821971016996, 0.063089014491502, 0.063089014491502}},
+		{0.082851075618374, []float64{0.053145049844817, 0.310352451033784, 0.636502499121399}},
+	},
+	{
+		{-0.149570044467682, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{0.175615257433208, []float64{0.479308067841920, 0.260345966079040, 0.260345966079040}},
+		{0.053347235608838, []float64{0.869739794195568, 0.065130102902216, 0.065130102902216}},
+		{0.077113760890257, []float64{0.048690315425316, 0.312865496004874, 0.638444188569810}},
+	},
+	{
+		{0.144315607677787, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{0.095091634267285, []float64{0.081414823414554, 0.459292588292723, 0.459292588292723}},
+		{0.103217370534718, []float64{0.658861384496480, 0.170569307751760, 0.170569307751760}},
+		{0.032458497623198, []float64{0.898905543365938, 0.050547228317031, 0.050547228317031}},
+		{0.027230314174435, []float64{0.008394777409958, 0.263112829634638, 0.728492392955404}},
+	},
+}
+
+// keastTables симметричные формулы Киста на тетраэдре степеней 1..6
+var keastTables = [][]simplexOrbit{
+	{
+		{1, []float64{0.25, 0.25, 0.25, 0.25}},
+	},
+	{
+		{0.25, []float64{0.5854101966249685, 0.1381966011250105, 0.1381966011250105, 0.1381966011250105}},
+	},
+	{
+		{-0.8, []float64{0.25, 0.25, 0.25, 0.25}},
+		{0.45, []float64{0.5, 1.0 / 6, 1.0 / 6, 1.0 / 6}},
+	},
+	{
+		{-0.0789333333333333, []float64{0.25, 0.25, 0.25, 0.25}},
+		{0.0457333333333333, []float64{11.0 / 14, 1.0 / 14, 1.0 / 14, 1.0 / 14}},
+		{0.1493333333333333, []float64{0.3994035761667992, 0.3994035761667992, 0.1005964238332008, 0.1005964238332008}},
+	},
+	{
+		{0.1817020685825351, []float64{0.25, 0.25, 0.25, 0.25}},
+		{0.0361607142857143, []float64{0, 1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{0.0698714945161738, []float64{8.0 / 11, 1.0 / 11, 1.0 / 11, 1.0 / 11}},
+		{0.0656948493683187, []float64{0.4334498464263357, 0.4334498464263357, 0.0665501535736643, 0.0665501535736643}},
+	},
+	{
+		{0.0399227502581679, []float64{0.3561913862225449, 0.2146028712591517, 0.2146028712591517, 0.2146028712591517}},
+		{0.0100772110553179, []float64{0.8779781243961660, 0.0406739585346113, 0.0406739585346113, 0.0406739585346113}},
+		{0.0553571815436544, []float64{0.0329863295731731, 0.3223378901422757, 0.3223378901422757, 0.3223378901422757}},
+		{27.0 / 560, []float64{0.6030056647916491, 0.2696723314583159, 0.0636610018750175, 0.0636610018750175}},
+	},
+}
+
+var (
+	triangleRules    = expandSimplexRules(dunavantTables)
+	tetrahedronRules = expandSimplexRules(keastTables)
+)
+
+// expandSimplexRules раскрывает группы симметричных узлов в списки узлов и весов
+func expandSimplexRules(tables [][]simplexOrbit) []simplexRule {
+	rules := make([]simplexRule, len(tables))
+	for i, orbits := range tables {
+		for _, orbit := range orbits {
+			for _, p := range permutations(orbit.point) {
+				rules[i].points = append(rules[i].points, p)
+				rules[i].weights = append(rules[i].weights, orbit.weight)
+			}
+		}
+	}
+
+	return rules
+}
+
+// permutations возвращает все различные перестановки координат p
+func permutations(p []float64) [][]float64 {
+	if len(p) == 1 {
+		return [][]float64{{p[0]}}
+	}
+
+	var result [][]float64
+	for i := range p {
+		// Первой ставим каждую из различных координат
+		repeated := false
+		for j := 0; j < i; j++ {
+			if p[j] == p[i] {
+				repeated = true
+				break
+			}
+		}
+		if repeated {
+			continue
+		}
+
+		rest := make([]float64, 0, len(p)-1)
+		rest = append(rest, p[:i]...)
+		rest = append(rest, p[i+1:]...)
+		for _, tail := range permutations(rest) {
+			result = append(result, append([]float64{p[i]}, tail...))
+		}
+	}
+
+	return result
+}
+
+// triangleArea возвращает площадь треугольника с вершинами p1, p2, p3
+func triangleArea(p1, p2, p3 [2]float64) float64 {
+	return math.Abs((p2[0]-p1[0])*(p3[1]-p1[1])-(p3[0]-p1[0])*(p2[1]-p1[1])) / 2
+}
+
+// tetrahedronVolume возвращает объем тетраэдра с вершинами p1, p2, p3, p4
+func tetrahedronVolume(p1, p2, p3, p4 [3]float64) float64 {
+	var u, v, w [3]float64
+	for k := 0; k < 3; k++ {
+		u[k], v[k], w[k] = p2[k]-p1[k], p3[k]-p1[k], p4[k]-p1[k]
+	}
+
+	det := u[0]*(v[1]*w[2]-v[2]*w[1]) - u[1]*(v[0]*w[2]-v[2]*w[0]) + u[2]*(v[0]*w[1]-v[1]*w[0])
+	return math.Abs(det) / 6
+}
+
+// IntegrateTriangle вычисляет приближенное значение интеграла функции f по треугольнику с вершинами p1, p2, p3
+// по формуле, точной для многочленов степени degree: для degree <= 8 используются симметричные формулы Данаванта,
+// для больших степеней - IntegrateTriangleDuffy с (degree+2)/2 узлами по каждой переменной
+func IntegrateTriangle(f integrand2D, p1, p2, p3 [2]float64, degree int) float64 {
+	if degree < 1 {
+		panic("integral: degree of the cubature rule must be positive")
+	}
+	if degree > len(triangleRules) {
+		return IntegrateTriangleDuffy(f, p1, p2, p3, (degree+2)/2)
+	}
+
+	rule := triangleRules[degree-1]
+	sum := 0.0
+	for i, l := range rule.points {
+		x := l[0]*p1[0] + l[1]*p2[0] + l[2]*p3[0]
+		y := l[0]*p1[1] + l[1]*p2[1] + l[2]*p3[1]
+		sum += rule.weights[i] * f(x, y)
+	}
+
+	return triangleArea(p1, p2, p3) * sum
+}
+
+// IntegrateTetrahedron вычисляет приближенное значение интеграла функции f по тетраэдру с вершинами p1, p2, p3, p4
+// по формуле, точной для многочленов степени degree: для degree <= 6 используются симметричные формулы Киста,
+// для больших степеней - IntegrateTetrahedronDuffy с (degree+2)/2 узлами по каждой переменной
+func IntegrateTetrahedron(f integrand3D, p1, p2, p3, p4 [3]float64, degree int) float64 {
+	if degree < 1 {
+		panic("integral: degree of the cubature rule must be positive")
+	}
+	if degree > len(tetrahedronRules) {
+		return IntegrateTetrahedronDuffy(f, p1, p2, p3, p4, (degree+2)/2)
+	}
+
+	rule := tetrahedronRules[degree-1]
+	vertices := [4][3]float64{p1, p2, p3, p4}
+	sum := 0.0
+	for i, l := range rule.points {
+		var x [3]float64
+		for j, p := range vertices {
+			for k := range x {
+				x[k] += l[j] * p[k]
+			}
+		}
+		sum += rule.weights[i] * f(x[0], x[1], x[2])
+	}
+
+	return tetrahedronVolume(p1, p2, p3, p4) * sum
+}
+
+// IntegrateTriangleDuffy вычисляет приближенное значение интеграла функции f по треугольнику с вершинами p1, p2, p3
+// с помощью преобразования Даффи квадрата [0, 1]² в треугольник: l1 = u, l2 = v(1-u), якобиан (1-u)
+// По u используются n узлов Гаусса-Якоби с весом (1-u), по v - n узлов Гаусса-Лежандра, поэтому формула
+// точна для многочленов степени 2n-1
+func IntegrateTriangleDuffy(f integrand2D, p1, p2, p3 [2]float64, n int) float64 {
+	if n < 1 {
+		panic("integral: number of nodes must be positive")
+	}
+
+	// Узлы с весом (1-t) на [-1, 1] переносятся на [0, 1], множитель 1/4 - от замены переменной и веса
+	nodesU := node.BuildGaussJacobiNodes(1, 0, n)
+	nodesV := node.BuildGaussLegendreNodes(0, 1, n)
+
+	sum := 0.0
+	for _, nu := range nodesU {
+		u := (1 + nu.X) / 2
+		for _, nv := range nodesV {
+			l1, l2 := u, nv.X*(1-u)
+			l3 := 1 - l1 - l2
+			x := l1*p1[0] + l2*p2[0] + l3*p3[0]
+			y := l1*p1[1] + l2*p2[1] + l3*p3[1]
+			sum += nu.Y / 4 * nv.Y * f(x, y)
+		}
+	}
+
+	// Площадь единичного треугольника равна 1/2
+	return 2 * triangleArea(p1, p2, p3) * sum
+}
+
+// IntegrateTetrahedronDuffy вычисляет приближенное значение интеграла функции f по тетраэдру с вершинами p1, p2, p3, p4
+// с помощью преобразования Даффи куба [0, 1]³ в тетраэдр: l1 = u, l2 = v(1-u), l3 = w(1-u)(1-v),
+// якобиан (1-u)²(1-v). Используются n узлов Гаусса-Якоби с весами (1-u)² и (1-v) и n узлов Гаусса-Лежандра по w,
+// поэтому формула точна для многочленов степени 2n-1
+func IntegrateTetrahedronDuffy(f integrand3D, p1, p2, p3, p4 [3]float64, n int) float64 {
+	if n < 1 {
+		panic("integral: number of nodes must be positive")
+	}
+
+	nodesU := node.BuildGaussJacobiNodes(2, 0, n)
+	nodesV := node.BuildGaussJacobiNodes(1, 0, n)
+	nodesW := node.BuildGaussLegendreNodes(0, 1, n)
+	vertices := [4][3]float64{p1, p2, p3, p4}
+
+	sum := 0.0
+	for _, nu := range nodesU {
+		u := (1 + nu.X) / 2
+		for _, nv := range nodesV {
+			v := (1 + nv.X) / 2
+			for _, nw := range nodesW {
+				l := [4]float64{u, v * (1 - u), nw.X * (1 - u) * (1 - v)}
+				l[3] = 1 - l[0] - l[1] - l[2]
+
+				var x [3]float64
+				for j, p := range vertices {
+					for k := range x {
+						x[k] += l[j] * p[k]
+					}
+				}
+				// Множители 1/8 и 1/4 - от замены переменных и весов Якоби
+				sum += nu.Y / 8 * nv.Y / 4 * nw.Y * f(x[0], x[1], x[2])
+			}
+		}
+	}
+
+	// Объем единичного тетраэдра равен 1/6
+	return 6 * tetrahedronVolume(p1, p2, p3, p4) * sum
+}
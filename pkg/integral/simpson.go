@@ -1,9 +1,10 @@
 package integral
 
 import (
-	"fmt"
+	"context"
 	"math"
-	"os"
+
+	"github.com/foreverNP/calmet/pkg/observer"
 )
 
 // rungeErrorSimpson вычисляет оценку погрешности методом Рунге для формулы Симпсона
@@ -11,51 +12,80 @@ func rungeErrorSimpson(q2, q float64) float64 {
 	return (q2 - q) / 15
 }
 
-// simpsonRule вычисляет приближенное значение интеграла функции f от a до b с помощью кф Симпсона
-func simpsonRule(f integrand, a, b float64, n int) float64 {
-	h := (b - a) / float64(n)
-	sum := 0.0
-
-	// Вычисляем сумму значений функции f в точках x с соответствующими коэффициентами
-	for i := 1; i <= n-1; i++ {
-		x := a + float64(i)*h
-		coeff := 0.0
+// simpsonRule вычисляет приближенное значение интеграла по кф Симпсона с n отрезками
+// через значения формулы трапеций с n и n/2 отрезками: S(n) = (4T(n) - T(n/2)) / 3
+func simpsonRule(tn, tHalf float64) float64 {
+	return (4*tn - tHalf) / 3
+}
 
-		if i%2 == 0 {
-			coeff = 2.0
-		} else {
-			coeff = 4.0
-		}
+// IntegrateSimpson вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
+// с помощью кф Симпсона и метода Рунге для оценки погрешности
+// При удвоении числа отрезков функция вычисляется только в новых точках
+func IntegrateSimpson(f integrand, a, b float64, e float64, obs observer.Observer) float64 {
+	result, _ := IntegrateSimpsonContext(context.Background(), f, a, b, e, obs)
 
-		sum += coeff * f(x)
-	}
+	return result.Value
+}
 
-	return (h / 3.0) * (f(a) + f(b) + sum)
+// IntegrateSimpsonContext работает как IntegrateSimpson, но проверяет ctx перед каждым удвоением числа отрезков
+// и возвращает результат с оценкой погрешности и количеством вычислений f
+// При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateSimpsonContext(ctx context.Context, f integrand, a, b float64, e float64,
+	obs observer.Observer) (Result, error) {
+	return IntegrateSimpsonWithOptions(ctx, f, a, b, e, Options{Observer: obs})
 }
 
-// IntegrateSimpson вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
-// с помощью кф Симпсона и метода Рунге для оценки погрешности
-func IntegrateSimpson(f integrand, a, b float64, e float64, logFile *os.File) float64 {
+// IntegrateSimpsonWithOptions работает как IntegrateSimpsonContext с параметрами opts (см. Options)
+// Новые точки каждого уровня вычисляются одним пакетом в opts.Workers потоках и суммируются в порядке возрастания x
+// Если следующий уровень превысил бы opts.MaxEvals вычислений f, возвращает последнее приближение и ErrEvalBudget
+func IntegrateSimpsonWithOptions(ctx context.Context, f integrand, a, b float64, e float64,
+	opts Options) (Result, error) {
 	n := 2
 	prevResult := 0.0
-	result := simpsonRule(f, a, b, n)
+
+	// Формулы трапеций с n/2 и n отрезками, из которых составляется формула Симпсона
+	tHalf := trapezoidalRule(f, a, b, n/2, opts.workers(), opts.Summation)
+	tn := trapezoidalRefine(f, a, b, n/2, tHalf, opts.workers(), opts.Summation)
+	result := simpsonRule(tn, tHalf)
+	evals := n + 1
 
 	// Вычисляем приближенное значение интеграла с заданной точностью e с помощью метода Рунге
 	for math.Abs(rungeErrorSimpson(result, prevResult)) > e {
-		if logFile != nil {
-			fmt.Fprintf(logFile, "h = %.10f, Q = %.10f, R = %.10f, n = %d\n",
-				(b-a)/float64(n), result, math.Abs(rungeErrorSimpson(result, prevResult)), n)
+		opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: result,
+			Error: math.Abs(rungeErrorSimpson(result, prevResult)), N: n})
+
+		if err := ctx.Err(); err != nil {
+			return Result{
+				Value:       result,
+				Error:       math.Abs(rungeErrorSimpson(result, prevResult)),
+				Evaluations: evals,
+				Intervals:   n,
+			}, err
+		}
+
+		if opts.exceeds(evals, n) {
+			return Result{
+				Value:       result,
+				Error:       math.Abs(rungeErrorSimpson(result, prevResult)),
+				Evaluations: evals,
+				Intervals:   n,
+			}, ErrEvalBudget
 		}
 
 		prevResult = result
+		tHalf, tn = tn, trapezoidalRefine(f, a, b, n, tn, opts.workers(), opts.Summation)
+		result = simpsonRule(tn, tHalf)
+		evals += n
 		n *= 2
-		result = simpsonRule(f, a, b, n)
 	}
 
-	if logFile != nil {
-		fmt.Fprintf(logFile, "h = %.10f, Q = %.10f, R = %.10f, n = %d\n",
-			(b-a)/float64(n), result, math.Abs(rungeErrorSimpson(result, prevResult)), n)
-	}
+	opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: result,
+		Error: math.Abs(rungeErrorSimpson(result, prevResult)), N: n})
 
-	return result
+	return Result{
+		Value:       result,
+		Error:       math.Abs(rungeErrorSimpson(result, prevResult)),
+		Evaluations: evals,
+		Intervals:   n,
+	}, nil
 }
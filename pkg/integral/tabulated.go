@@ -0,0 +1,104 @@
+package integral
+
+import (
+	"github.com/foreverNP/calmet/pkg/node"
+	"github.com/foreverNP/calmet/pkg/spline"
+)
+
+// checkSamples проверяет, что таблица содержит не менее двух узлов с возрастающими X
+func checkSamples(nodes []node.Node) {
+	if len(nodes) < 2 {
+		panic("integral: at least two samples are required")
+	}
+
+	for i := 1; i < len(nodes); i++ {
+		if nodes[i].X <= nodes[i-1].X {
+			panic("integral: sample abscissas must be strictly increasing")
+		}
+	}
+}
+
+// IntegrateTrapezoidalSamples вычисляет приближенное значение интеграла от первого до последнего узла
+// по таблице значений nodes (X - узлы в порядке возрастания, Y - значения функции) по формуле трапеций
+// для неравномерной сетки
+func IntegrateTrapezoidalSamples(nodes []node.Node) float64 {
+	cumulative := IntegrateCumulative(nodes)
+	return cumulative[len(cumulative)-1]
+}
+
+// IntegrateCumulative вычисляет по формуле трапеций интегралы от первого узла таблицы nodes до каждого из узлов
+// Возвращает слайс той же длины, что и nodes, первый элемент которого равен 0
+func IntegrateCumulative(nodes []node.Node) []float64 {
+	checkSamples(nodes)
+
+	result := make([]float64, len(nodes))
+	for i := 1; i < len(nodes); i++ {
+		result[i] = result[i-1] + (nodes[i].X-nodes[i-1].X)*(nodes[i-1].Y+nodes[i].Y)/2
+	}
+
+	return result
+}
+
+// IntegrateSimpsonSamples вычисляет приближенное значение интеграла от первого до последнего узла
+// по таблице значений nodes по формуле Симпсона для неравномерной сетки: на каждой паре соседних отрезков
+// интегрируется парабола, проходящая через три узла. При нечетном количестве отрезков интеграл по последнему
+// отрезку вычисляется по параболе, проходящей через три последних узла. Для двух узлов используется формула трапеций
+func IntegrateSimpsonSamples(nodes []node.Node) float64 {
+	checkSamples(nodes)
+
+	n := len(nodes) - 1 // количество отрезков
+	if n == 1 {
+		return (nodes[1].X - nodes[0].X) * (nodes[0].Y + nodes[1].Y) / 2
+	}
+
+	sum := 0.0
+	for i := 0; i+2 <= n; i += 2 {
+		h0 := nodes[i+1].X - nodes[i].X
+		h1 := nodes[i+2].X - nodes[i+1].X
+		sum += (h0 + h1) / 6 * ((2-h1/h0)*nodes[i].Y + (h0+h1)*(h0+h1)/(h0*h1)*nodes[i+1].Y + (2-h0/h1)*nodes[i+2].Y)
+	}
+
+	if n%2 == 1 {
+		h0 := nodes[n-1].X - nodes[n-2].X
+		h1 := nodes[n].X - nodes[n-1].X
+		sum += (2*h1*h1+3*h0*h1)/(6*(h0+h1))*nodes[n].Y +
+			(h1*h1+3*h0*h1)/(6*h0)*nodes[n-1].Y -
+			h1*h1*h1/(6*h0*(h0+h1))*nodes[n-2].Y
+	}
+
+	return sum
+}
+
+// IntegrateSplineSamples вычисляет приближенное значение интеграла от первого до последнего узла
+// по таблице значений nodes как точный интеграл интерполирующего кубического сплайна
+// Производные на концах, необходимые для построения сплайна, оцениваются по параболе через три крайних узла
+// (для двух узлов - по хорде)
+func IntegrateSplineSamples(nodes []node.Node) float64 {
+	checkSamples(nodes)
+
+	n := len(nodes) - 1
+	left, right := nodes[0].X, nodes[n].X
+	dLeft := (nodes[1].Y - nodes[0].Y) / (nodes[1].X - nodes[0].X)
+	dRight := (nodes[n].Y - nodes[n-1].Y) / (nodes[n].X - nodes[n-1].X)
+	if n >= 2 {
+		dLeft = parabolaDerivative(nodes[0], nodes[1], nodes[2], left)
+		dRight = parabolaDerivative(nodes[n-2], nodes[n-1], nodes[n], right)
+	}
+
+	spl := spline.New(func(x float64) float64 {
+		if x == left {
+			return dLeft
+		}
+		return dRight
+	}, nodes)
+
+	cumulative := spl.CumulativeIntegral()
+	return cumulative[n]
+}
+
+// parabolaDerivative вычисляет в точке x производную параболы, проходящей через узлы n0, n1, n2
+func parabolaDerivative(n0, n1, n2 node.Node, x float64) float64 {
+	return n0.Y*(2*x-n1.X-n2.X)/((n0.X-n1.X)*(n0.X-n2.X)) +
+		n1.Y*(2*x-n0.X-n2.X)/((n1.X-n0.X)*(n1.X-n2.X)) +
+		n2.Y*(2*x-n0.X-n1.X)/((n2.X-n0.X)*(n2.X-n1.X))
+}
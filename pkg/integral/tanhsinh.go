@@ -0,0 +1,149 @@
+package integral
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+)
+
+const (
+	tanhSinhMaxT      = 4.0 // граница отрезка [-tmax, tmax] по переменной t (узлы ближе 1e-37 к концам)
+	tanhSinhMaxLevels = 12  // максимальное количество уровней уменьшения шага вдвое
+)
+
+// ErrNotConverged возвращается, если итерационный метод не достиг заданной точности
+var ErrNotConverged = errors.New("integral: required accuracy was not achieved")
+
+// integrandComplement представляет функцию f(x, xc), которой кроме x передается расстояние со знаком xc от x
+// до ближайшего конца отрезка: xc = a - x в левой половине [a, b] и xc = b - x в правой. xc вычисляется без
+// вычитания близких чисел, поэтому сохраняет относительную точность и там, где x совпадает с концом отрезка
+type integrandComplement func(x, xc float64) float64
+
+// tanhSinhSum вычисляет сумму w(t)·(f(x(t)) + f(x(-t))) по узлам t = k·h, k = first, first+step, ...
+// При complement = false узлы, совпадающие с концами отрезка в арифметике с плавающей точкой, пропускаются,
+// а интеграл по недоступной окрестности конца оценивается величиной 2|f(x)|·|x - c| в ближайшем к концу c
+// вычисленном узле x
+// Возвращает сумму, оценку пропущенной части интеграла и количество вычислений f
+func tanhSinhSum(f integrandComplement, a, b float64, h float64, first, step int,
+	complement bool) (float64, float64, int) {
+	half := (b - a) / 2
+	lo, hi := math.Min(a, b), math.Max(a, b)
+
+	sum, tail := 0.0, 0.0
+	evals := 0
+	var last [2]float64 // оценка интеграла по окрестности каждого конца до последнего вычисленного узла
+	var dropped [2]bool
+	for k := first; float64(k)*h <= tanhSinhMaxT; k += step {
+		t := float64(k) * h
+		u := math.Pi / 2 * math.Sinh(t)
+		cu := math.Cosh(u)
+		w := math.Pi / 2 * math.Cosh(t) / (cu * cu)
+		if w == 0 {
+			break
+		}
+
+		if k == 0 {
+			sum += w * f((a+b)/2, half)
+			evals++
+			continue
+		}
+
+		// Расстояние до конца отрезка δ = 1 - tanh(u) вычисляем без вычитания близких чисел
+		delta := 2 / (1 + math.Exp(2*u))
+		for side, xc := range [2]float64{-half * delta, half * delta} {
+			x := a - xc
+			if side == 1 {
+				x = b - xc
+			}
+			if xc == 0 || (!complement && (x <= lo || x >= hi)) {
+				if !complement && !dropped[side] {
+					dropped[side] = true
+					tail += last[side]
+				}
+				continue
+			}
+
+			// Интеграл по окрестности конца длины d, где |f| ~ d^(-1/2), равен 2|f(d)|·d. Длина считается
+			// по округленному узлу x: около конца, не равного нулю, она может заметно отличаться от |xc|
+			value := f(x, xc)
+			sum += w * value
+			last[side] = 2 * math.Abs(value*(x-[2]float64{a, b}[side]))
+			evals++
+		}
+	}
+
+	return sum, tail, evals
+}
+
+// IntegrateTanhSinh вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
+// методом двойной экспоненты (tanh-sinh): x = (a+b)/2 + (b-a)/2·tanh(π/2·sinh t). Узлы сгущаются к концам
+// отрезка, но не совпадают с ними, поэтому метод пригоден для интегрируемых особенностей на концах (x^(-1/2), ln x)
+// Шаг по t уменьшается вдвое на каждом уровне, функция вычисляется только в новых узлах,
+// погрешность оценивается по разности приближений соседних уровней
+// Узлы, совпадающие с концами в арифметике с плавающей точкой, пропускаются, а интеграл по недоступной окрестности
+// конца оценивается и добавляется к погрешности. Около конца b ≠ 0 расстояние до него известно лишь с точностью
+// ulp(b), поэтому для особенностей на таком конце (1/√(1-x)) следует использовать IntegrateTanhSinhComplement
+// Возвращает результат и ErrNotConverged, если точность не достигнута
+func IntegrateTanhSinh(f integrand, a, b float64, e float64, obs observer.Observer) (Result, error) {
+	return IntegrateTanhSinhContext(context.Background(), f, a, b, e, obs)
+}
+
+// IntegrateTanhSinhContext работает как IntegrateTanhSinh, но проверяет ctx перед каждым уменьшением шага
+// При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateTanhSinhContext(ctx context.Context, f integrand, a, b float64, e float64,
+	obs observer.Observer) (Result, error) {
+	return tanhSinh(ctx, func(x, _ float64) float64 { return f(x) }, a, b, e, false, obs)
+}
+
+// IntegrateTanhSinhComplement работает как IntegrateTanhSinh, но передает f кроме x точное расстояние xc до
+// ближайшего конца отрезка (см. integrandComplement). Узлы у концов не пропускаются, поэтому особенность на любом
+// конце можно вычислить через xc, например 1/√(1-x) как 1/√xc в правой половине [0, 1]
+func IntegrateTanhSinhComplement(f integrandComplement, a, b float64, e float64, obs observer.Observer) (Result, error) {
+	return IntegrateTanhSinhComplementContext(context.Background(), f, a, b, e, obs)
+}
+
+// IntegrateTanhSinhComplementContext работает как IntegrateTanhSinhComplement, но проверяет ctx перед каждым
+// уменьшением шага. При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateTanhSinhComplementContext(ctx context.Context, f integrandComplement, a, b float64, e float64,
+	obs observer.Observer) (Result, error) {
+	return tanhSinh(ctx, f, a, b, e, true, obs)
+}
+
+// tanhSinh реализует IntegrateTanhSinhContext и IntegrateTanhSinhComplementContext (см. tanhSinhSum)
+func tanhSinh(ctx context.Context, f integrandComplement, a, b float64, e float64, complement bool,
+	obs observer.Observer) (Result, error) {
+	half := (b - a) / 2
+
+	h := 1.0
+	sum, _, evals := tanhSinhSum(f, a, b, h, 0, 1, complement)
+	result := Result{Value: half * h * sum, Error: math.Inf(1), Evaluations: evals}
+
+	for level := 1; level <= tanhSinhMaxLevels; level++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		h /= 2
+		newSum, tail, newEvals := tanhSinhSum(f, a, b, h, 1, 2, complement)
+		sum += newSum
+
+		value := half * h * sum
+		result.Error = math.Abs(value-result.Value) + tail
+		result.Value = value
+		result.Evaluations += newEvals
+		result.Intervals = int(2 * tanhSinhMaxT / h)
+
+		if obs != nil {
+			obs.Observe(observer.Step{H: h, Estimate: result.Value,
+				Error: result.Error, N: result.Intervals})
+		}
+
+		if result.Error <= e {
+			return result, nil
+		}
+	}
+
+	return result, ErrNotConverged
+}
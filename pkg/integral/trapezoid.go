@@ -1,56 +1,113 @@
 package integral
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"math"
-	"os"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
 )
 
 // integrand представляет функцию, которую необходимо интегрировать
 type integrand func(float64) float64
 
+// ErrEvalBudget возвращается, если заданная точность не достигнута за допустимое число вычислений функции
+var ErrEvalBudget = errors.New("integral: maximum number of function evaluations exceeded")
+
 // rungeErrorTrapezoid вычисляет оценку погрешности методом Рунге
 func rungeErrorTrapezoid(q2, q float64) float64 {
 	return (q2 - q) / 3
 }
 
 // trapezoidalRule вычисляет приближенное значение интеграла функции f от a до b
-func trapezoidalRule(f integrand, a, b float64, n int) float64 {
+// Значения функции вычисляются в workers потоках (см. evaluatePoints) и складываются способом summation
+func trapezoidalRule(f integrand, a, b float64, n, workers int, summation tools.Summation) float64 {
 	h := (b - a) / float64(n)
-	sum := 0.0
+	values := evaluatePoints(f, n+1, func(i int) float64 {
+		return a + float64(i)*h
+	}, workers)
 
-	// Вычисляем сумму значений функции f в точках x
-	for i := 1; i <= n-1; i++ {
-		x := a + float64(i)*h
-		sum += f(x)
-	}
+	// Вычисляем сумму значений функции f во внутренних точках x
+	sum := tools.Sum(values[1:n], summation)
+
+	return 0.5 * h * (values[0] + 2*sum + values[n])
+}
+
+// trapezoidalRefine уточняет значение q формулы трапеций с n отрезками до значения с 2n отрезками,
+// вычисляя функцию f только в новых точках - серединах текущих отрезков (в workers потоках)
+func trapezoidalRefine(f integrand, a, b float64, n int, q float64, workers int, summation tools.Summation) float64 {
+	h := (b - a) / float64(n)
+	values := evaluatePoints(f, n, func(i int) float64 {
+		return a + (float64(i)+0.5)*h
+	}, workers)
 
-	return 0.5 * h * (f(a) + 2*sum + f(b))
+	return 0.5*q + 0.5*h*tools.Sum(values, summation)
 }
 
 // IntegrateTrapezoidal вычисляет приближенное значение интеграла функции f от a до b с заданной точностью e
 // с помощью кф трапеций и метода Рунге для оценки погрешности
-func IntegrateTrapezoidal(f integrand, a, b float64, e float64, logFile *os.File) float64 {
+// При удвоении числа отрезков функция вычисляется только в новых точках
+func IntegrateTrapezoidal(f integrand, a, b float64, e float64, obs observer.Observer) float64 {
+	result, _ := IntegrateTrapezoidalContext(context.Background(), f, a, b, e, obs)
+
+	return result.Value
+}
+
+// IntegrateTrapezoidalContext работает как IntegrateTrapezoidal, но проверяет ctx перед каждым удвоением числа отрезков
+// и возвращает результат с оценкой погрешности и количеством вычислений f
+// При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateTrapezoidalContext(ctx context.Context, f integrand, a, b float64, e float64,
+	obs observer.Observer) (Result, error) {
+	return IntegrateTrapezoidalWithOptions(ctx, f, a, b, e, Options{Observer: obs})
+}
+
+// IntegrateTrapezoidalWithOptions работает как IntegrateTrapezoidalContext с параметрами opts (см. Options)
+// Новые точки каждого уровня вычисляются одним пакетом в opts.Workers потоках и суммируются в порядке возрастания x
+// Если следующий уровень превысил бы opts.MaxEvals вычислений f, возвращает последнее приближение и ErrEvalBudget
+func IntegrateTrapezoidalWithOptions(ctx context.Context, f integrand, a, b float64, e float64,
+	opts Options) (Result, error) {
 	n := 2
 	prevResult := 0.0
-	result := trapezoidalRule(f, a, b, n)
+	result := trapezoidalRule(f, a, b, n, opts.workers(), opts.Summation)
+	evals := n + 1
 
 	// Вычисляем приближенное значение интеграла с заданной точностью e с помощью метода Рунге
 	for math.Abs(rungeErrorTrapezoid(result, prevResult)) > e {
-		if logFile != nil {
-			fmt.Fprintf(logFile, "h = %.10f, Q = %.10f, R = %.10f, n = %d\n",
-				(b-a)/float64(n), result, math.Abs(rungeErrorTrapezoid(result, prevResult)), n)
+		opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: result,
+			Error: math.Abs(rungeErrorTrapezoid(result, prevResult)), N: n})
+
+		if err := ctx.Err(); err != nil {
+			return Result{
+				Value:       result,
+				Error:       math.Abs(rungeErrorTrapezoid(result, prevResult)),
+				Evaluations: evals,
+				Intervals:   n,
+			}, err
+		}
+
+		if opts.exceeds(evals, n) {
+			return Result{
+				Value:       result,
+				Error:       math.Abs(rungeErrorTrapezoid(result, prevResult)),
+				Evaluations: evals,
+				Intervals:   n,
+			}, ErrEvalBudget
 		}
 
 		prevResult = result
+		result = trapezoidalRefine(f, a, b, n, result, opts.workers(), opts.Summation)
+		evals += n
 		n *= 2
-		result = trapezoidalRule(f, a, b, n)
 	}
 
-	if logFile != nil {
-		fmt.Fprintf(logFile, "h = %.10f, Q = %.10f, R = %.10f, n = %d\n",
-			(b-a)/float64(n), result, math.Abs(rungeErrorTrapezoid(result, prevResult)), n)
-	}
+	opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: result,
+		Error: math.Abs(rungeErrorTrapezoid(result, prevResult)), N: n})
 
-	return result
+	return Result{
+		Value:       result,
+		Error:       math.Abs(rungeErrorTrapezoid(result, prevResult)),
+		Evaluations: evals,
+		Intervals:   n,
+	}, nil
 }
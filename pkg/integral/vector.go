@@ -0,0 +1,336 @@
+package integral
+
+import (
+	"context"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/observer"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// integrandVector представляет вектор-функцию, все компоненты которой интегрируются одновременно
+// Функция должна возвращать слайсы одной и той же длины; возвращенный слайс копируется, поэтому его можно переиспользовать
+type integrandVector func(float64) []float64
+
+// integrandComplex представляет комплекснозначную функцию, которую необходимо интегрировать
+type integrandComplex func(float64) complex128
+
+// VectorResult представляет результат адаптивного интегрирования вектор-функции.
+type VectorResult struct {
+	Value       []float64 // Приближенные значения интегралов компонент.
+	Errors      []float64 // Оценки абсолютной погрешности компонент.
+	Error       float64   // Равномерная норма оценок погрешности компонент, по которой контролируется точность.
+	Evaluations int       // Количество вычислений подынтегральной функции.
+	Intervals   int       // Количество подотрезков итогового разбиения.
+}
+
+// ComplexResult представляет результат адаптивного интегрирования комплекснозначной функции.
+type ComplexResult struct {
+	Value       complex128 // Приближенное значение интеграла.
+	Error       float64    // Наибольшая из оценок погрешности вещественной и мнимой частей.
+	Evaluations int        // Количество вычислений подынтегральной функции.
+	Intervals   int        // Количество подотрезков итогового разбиения.
+}
+
+// complexAsVector представляет комплекснозначную функцию как вектор-функцию из вещественной и мнимой частей
+func complexAsVector(f integrandComplex) integrandVector {
+	return func(x float64) []float64 {
+		v := f(x)
+		return []float64{real(v), imag(v)}
+	}
+}
+
+// complexResult собирает ComplexResult из результата интегрирования вещественной и мнимой частей
+func complexResult(r VectorResult) ComplexResult {
+	return ComplexResult{
+		Value:       complex(r.Value[0], r.Value[1]),
+		Error:       r.Error,
+		Evaluations: r.Evaluations,
+		Intervals:   r.Intervals,
+	}
+}
+
+// kronrodValuesVector вычисляет интегралы всех компонент вектор-функции на [a, b] по формуле Кронрода и оценки
+// их погрешности по значениям f в узлах kronrodPoints (см. kronrodValues)
+func kronrodValuesVector(values [][]float64, a, b float64, table kronrodTable) ([]float64, []float64) {
+	d := len(values[0])
+	c := len(table.xgk) - 1
+	fv1 := make([]float64, c)
+	fv2 := make([]float64, c)
+
+	integrals := make([]float64, d)
+	errs := make([]float64, d)
+	for k := 0; k < d; k++ {
+		for j := 0; j < c; j++ {
+			fv1[j] = values[2*j+1][k]
+			fv2[j] = values[2*j+2][k]
+		}
+		integrals[k], errs[k] = kronrodEstimate(values[0][k], fv1, fv2, (b-a)/2, table)
+	}
+
+	return integrals, errs
+}
+
+// vectorInterval подотрезок разбиения в адаптивном методе Гаусса-Кронрода для вектор-функции
+type vectorInterval struct {
+	a, b  float64
+	value []float64
+	err   []float64
+	norm  float64 // равномерная норма err
+}
+
+// IntegrateGaussKronrodVector вычисляет приближенные значения интегралов всех компонент вектор-функции f от a до b
+// с заданной точностью e адаптивным методом Гаусса-Кронрода: все компоненты вычисляются на общем разбиении,
+// делится подотрезок с наибольшей нормой оценки погрешности, точность контролируется по равномерной норме
+// оценок погрешности компонент. Экстраполяция по Винну не применяется
+// rule - пара формул, limit - максимальное количество подотрезков
+// Возвращает результат и ErrIntervalLimit, если точность не достигнута (ErrRoundoff, если деление стало невозможным)
+func IntegrateGaussKronrodVector(f integrandVector, a, b float64, e float64, rule KronrodRule,
+	limit int) (VectorResult, error) {
+	return IntegrateGaussKronrodVectorContext(context.Background(), f, a, b, e, rule, limit)
+}
+
+// IntegrateGaussKronrodVectorContext работает как IntegrateGaussKronrodVector, но проверяет ctx перед каждым
+// делением подотрезка. При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateGaussKronrodVectorContext(ctx context.Context, f integrandVector, a, b float64, e float64,
+	rule KronrodRule, limit int) (VectorResult, error) {
+	return IntegrateGaussKronrodVectorWithOptions(ctx, f, a, b, e, rule, limit, Options{})
+}
+
+// IntegrateGaussKronrodVectorWithOptions работает как IntegrateGaussKronrodVectorContext с параметрами opts
+// (см. Options). Узлы двух половин делимого подотрезка вычисляются одним пакетом в opts.Workers потоках
+// Суммы по подотрезкам накапливаются по мере деления способом opts.Summation (tools.PairwiseSum, требующий всех
+// слагаемых сразу, заменяется на tools.NeumaierSum). opts.Observer получает те же шаги, что и в
+// IntegrateGaussKronrodWithOptions, с равномерными нормами значения и оценки погрешности. Если деление превысило бы
+// opts.MaxEvals вычислений f, возвращает последнее приближение и ErrEvalBudget
+func IntegrateGaussKronrodVectorWithOptions(ctx context.Context, f integrandVector, a, b float64, e float64,
+	rule KronrodRule, limit int, opts Options) (VectorResult, error) {
+	table, ok := kronrodTables[rule]
+	if !ok {
+		panic("integral: unknown Gauss-Kronrod rule")
+	}
+
+	points := kronrodPoints(a, b, table)
+	value, errs := kronrodValuesVector(evaluateVectors(f, len(points), func(i int) float64 { return points[i] }, -1,
+		opts.workers()), a, b, table)
+	evals := len(points)
+	d := len(value)
+	intervals := []vectorInterval{{a: a, b: b, value: value, err: errs, norm: tools.UniformNorm(errs)}}
+	result := VectorResult{Value: make([]float64, d), Errors: make([]float64, d), Error: intervals[0].norm,
+		Evaluations: evals, Intervals: 1}
+	copy(result.Value, value)
+	copy(result.Errors, errs)
+	opts.observe(observer.Step{H: math.Abs(b - a), Estimate: tools.UniformNorm(result.Value), Error: result.Error, N: 1})
+
+	// Суммы по подотрезкам обновляются при каждом делении, а не пересчитываются заново
+	valueSum := make([]tools.Accumulator, d)
+	errSum := make([]tools.Accumulator, d)
+	for k := 0; k < d; k++ {
+		valueSum[k], errSum[k] = tools.NewAccumulator(opts.Summation), tools.NewAccumulator(opts.Summation)
+		valueSum[k].Add(value[k])
+		errSum[k].Add(errs[k])
+	}
+
+	stalled := false
+	for len(intervals) < limit && result.Error > e {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if opts.exceeds(result.Evaluations, 2*len(points)) {
+			return result, ErrEvalBudget
+		}
+
+		// Подотрезок с наибольшей нормой оценки погрешности
+		worst := 0
+		for i := range intervals {
+			if intervals[i].norm > intervals[worst].norm {
+				worst = i
+			}
+		}
+
+		iv := intervals[worst]
+		mid := (iv.a + iv.b) / 2
+		if mid <= math.Min(iv.a, iv.b) || mid >= math.Max(iv.a, iv.b) {
+			// Отрезок нельзя делить дальше в арифметике с плавающей точкой
+			stalled = true
+			break
+		}
+
+		// Узлы обеих половин вычисляются одним пакетом
+		points := append(kronrodPoints(iv.a, mid, table), kronrodPoints(mid, iv.b, table)...)
+		values := evaluateVectors(f, len(points), func(i int) float64 { return points[i] }, d, opts.workers())
+		k := len(points) / 2
+		v1, e1 := kronrodValuesVector(values[:k], iv.a, mid, table)
+		v2, e2 := kronrodValuesVector(values[k:], mid, iv.b, table)
+		result.Evaluations += len(points)
+
+		intervals[worst] = vectorInterval{a: iv.a, b: mid, value: v1, err: e1, norm: tools.UniformNorm(e1)}
+		intervals = append(intervals, vectorInterval{a: mid, b: iv.b, value: v2, err: e2, norm: tools.UniformNorm(e2)})
+
+		for k := 0; k < d; k++ {
+			valueSum[k].Add(v1[k])
+			valueSum[k].Add(v2[k])
+			valueSum[k].Add(-iv.value[k])
+			errSum[k].Add(e1[k])
+			errSum[k].Add(e2[k])
+			errSum[k].Add(-iv.err[k])
+			result.Value[k] = valueSum[k].Sum()
+			result.Errors[k] = math.Max(errSum[k].Sum(), 0)
+		}
+		result.Error = tools.UniformNorm(result.Errors)
+		result.Intervals = len(intervals)
+		opts.observe(observer.Step{H: math.Abs(mid - iv.a), Estimate: tools.UniformNorm(result.Value),
+			Error: result.Error, N: result.Intervals})
+	}
+
+	if result.Error > e && stalled {
+		return result, ErrRoundoff
+	}
+	if result.Error > e {
+		return result, ErrIntervalLimit
+	}
+
+	return result, nil
+}
+
+// IntegrateGaussKronrodComplex вычисляет приближенное значение интеграла комплекснозначной функции f от a до b
+// с заданной точностью e: вещественная и мнимая части интегрируются одновременно IntegrateGaussKronrodVector
+func IntegrateGaussKronrodComplex(f integrandComplex, a, b float64, e float64, rule KronrodRule,
+	limit int) (ComplexResult, error) {
+	return IntegrateGaussKronrodComplexContext(context.Background(), f, a, b, e, rule, limit)
+}
+
+// IntegrateGaussKronrodComplexContext работает как IntegrateGaussKronrodComplex, но проверяет ctx перед каждым
+// делением подотрезка. При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateGaussKronrodComplexContext(ctx context.Context, f integrandComplex, a, b float64, e float64,
+	rule KronrodRule, limit int) (ComplexResult, error) {
+	return IntegrateGaussKronrodComplexWithOptions(ctx, f, a, b, e, rule, limit, Options{})
+}
+
+// IntegrateGaussKronrodComplexWithOptions работает как IntegrateGaussKronrodComplexContext с параметрами opts
+// (см. IntegrateGaussKronrodVectorWithOptions)
+func IntegrateGaussKronrodComplexWithOptions(ctx context.Context, f integrandComplex, a, b float64, e float64,
+	rule KronrodRule, limit int, opts Options) (ComplexResult, error) {
+	result, err := IntegrateGaussKronrodVectorWithOptions(ctx, complexAsVector(f), a, b, e, rule, limit, opts)
+
+	return complexResult(result), err
+}
+
+// componentSum складывает k-е компоненты значений values[lo..hi-1] способом summation
+func componentSum(values [][]float64, k, lo, hi int, summation tools.Summation) float64 {
+	column := make([]float64, hi-lo)
+	for i := range column {
+		column[i] = values[lo+i][k]
+	}
+
+	return tools.Sum(column, summation)
+}
+
+// trapezoidalRuleVector вычисляет формулу трапеций с n отрезками для всех компонент вектор-функции f
+// (размерность определяется по значению f в точке a). Значения f вычисляются в workers потоках
+// и складываются способом summation
+func trapezoidalRuleVector(f integrandVector, a, b float64, n, workers int, summation tools.Summation) []float64 {
+	h := (b - a) / float64(n)
+	values := evaluateVectors(f, n+1, func(i int) float64 { return a + float64(i)*h }, -1, workers)
+
+	result := make([]float64, len(values[0]))
+	for k := range result {
+		result[k] = 0.5 * h * (values[0][k] + 2*componentSum(values, k, 1, n, summation) + values[n][k])
+	}
+
+	return result
+}
+
+// trapezoidalRefineVector уточняет значения q формулы трапеций с n отрезками до значений с 2n отрезками,
+// вычисляя f только в серединах текущих отрезков (в workers потоках)
+func trapezoidalRefineVector(f integrandVector, a, b float64, n int, q []float64, workers int,
+	summation tools.Summation) []float64 {
+	h := (b - a) / float64(n)
+	values := evaluateVectors(f, n, func(i int) float64 { return a + (float64(i)+0.5)*h }, len(q), workers)
+
+	refined := make([]float64, len(q))
+	for k := range refined {
+		refined[k] = 0.5*q[k] + 0.5*h*componentSum(values, k, 0, n, summation)
+	}
+
+	return refined
+}
+
+// IntegrateSimpsonVector вычисляет приближенные значения интегралов всех компонент вектор-функции f от a до b
+// с заданной точностью e по кф Симпсона, удваивая общее для всех компонент число отрезков
+// Точность контролируется по равномерной норме оценок Рунге
+func IntegrateSimpsonVector(f integrandVector, a, b float64, e float64) (VectorResult, error) {
+	return IntegrateSimpsonVectorContext(context.Background(), f, a, b, e)
+}
+
+// IntegrateSimpsonVectorContext работает как IntegrateSimpsonVector, но проверяет ctx перед каждым удвоением
+// числа отрезков. При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateSimpsonVectorContext(ctx context.Context, f integrandVector, a, b float64, e float64) (VectorResult, error) {
+	return IntegrateSimpsonVectorWithOptions(ctx, f, a, b, e, Options{})
+}
+
+// IntegrateSimpsonVectorWithOptions работает как IntegrateSimpsonVectorContext с параметрами opts (см. Options)
+// Новые точки каждого уровня вычисляются одним пакетом в opts.Workers потоках, значения каждой компоненты
+// суммируются в порядке возрастания x способом opts.Summation. opts.Observer получает шаг, равномерные нормы
+// значения и оценки погрешности и количество отрезков каждого уровня. Если следующий уровень превысил бы
+// opts.MaxEvals вычислений f, возвращает последнее приближение и ErrEvalBudget
+func IntegrateSimpsonVectorWithOptions(ctx context.Context, f integrandVector, a, b float64, e float64,
+	opts Options) (VectorResult, error) {
+	n := 2
+
+	tHalf := trapezoidalRuleVector(f, a, b, n/2, opts.workers(), opts.Summation)
+	tn := trapezoidalRefineVector(f, a, b, n/2, tHalf, opts.workers(), opts.Summation)
+	d := len(tn)
+	result := VectorResult{Value: make([]float64, d), Errors: make([]float64, d), Evaluations: n + 1, Intervals: n}
+	prev := make([]float64, d)
+
+	update := func() {
+		for k := 0; k < d; k++ {
+			result.Value[k] = simpsonRule(tn[k], tHalf[k])
+			result.Errors[k] = math.Abs(rungeErrorSimpson(result.Value[k], prev[k]))
+		}
+		result.Error = tools.UniformNorm(result.Errors)
+		opts.observe(observer.Step{H: (b - a) / float64(n), Estimate: tools.UniformNorm(result.Value),
+			Error: result.Error, N: n})
+	}
+	update()
+
+	for result.Error > e {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if opts.exceeds(result.Evaluations, n) {
+			return result, ErrEvalBudget
+		}
+
+		copy(prev, result.Value)
+		tHalf, tn = tn, trapezoidalRefineVector(f, a, b, n, tn, opts.workers(), opts.Summation)
+		result.Evaluations += n
+		n *= 2
+		result.Intervals = n
+		update()
+	}
+
+	return result, nil
+}
+
+// IntegrateSimpsonComplex вычисляет приближенное значение интеграла комплекснозначной функции f от a до b
+// с заданной точностью e: вещественная и мнимая части интегрируются одновременно IntegrateSimpsonVector
+func IntegrateSimpsonComplex(f integrandComplex, a, b float64, e float64) (ComplexResult, error) {
+	return IntegrateSimpsonComplexContext(context.Background(), f, a, b, e)
+}
+
+// IntegrateSimpsonComplexContext работает как IntegrateSimpsonComplex, но проверяет ctx перед каждым удвоением
+// числа отрезков. При отмене ctx или истечении его срока возвращает последнее приближение и ctx.Err()
+func IntegrateSimpsonComplexContext(ctx context.Context, f integrandComplex, a, b float64, e float64) (ComplexResult, error) {
+	return IntegrateSimpsonComplexWithOptions(ctx, f, a, b, e, Options{})
+}
+
+// IntegrateSimpsonComplexWithOptions работает как IntegrateSimpsonComplexContext с параметрами opts
+// (см. IntegrateSimpsonVectorWithOptions)
+func IntegrateSimpsonComplexWithOptions(ctx context.Context, f integrandComplex, a, b float64, e float64,
+	opts Options) (ComplexResult, error) {
+	result, err := IntegrateSimpsonVectorWithOptions(ctx, complexAsVector(f), a, b, e, opts)
+
+	return complexResult(result), err
+}
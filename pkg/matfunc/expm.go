@@ -0,0 +1,57 @@
+package matfunc
+
+import (
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	padeDegree = 6 // степень диагональной аппроксимации Паде для экспоненты
+)
+
+// Expm вычисляет матричную экспоненту e^A методом масштабирования и возведения в квадрат
+// с диагональной аппроксимацией Паде степени 6
+// A - квадратная матрица
+func Expm(A [][]float64) [][]float64 {
+	N := len(A)
+
+	// Масштабирование: наименьшее j >= 0, при котором ||A / 2^j|| <= 1/2
+	j := 0
+	if norm := tools.MatrixNorm(A); norm > 0.5 {
+		j = int(math.Ceil(math.Log2(norm / 0.5)))
+	}
+	As := tools.MultiplyMatrixByScalar(A, math.Ldexp(1, -j))
+
+	// Числитель N(A) и знаменатель D(A) аппроксимации Паде
+	c := 0.5
+	X := As
+	num := tools.AddMatrices(tools.IdentityMatrix(N), tools.MultiplyMatrixByScalar(As, c))
+	den := tools.AddMatrices(tools.IdentityMatrix(N), tools.MultiplyMatrixByScalar(As, -c))
+	for k := 2; k <= padeDegree; k++ {
+		c = c * float64(padeDegree-k+1) / float64(k*(2*padeDegree-k+1))
+		X = tools.MultiplyMatrices(As, X)
+
+		num = tools.AddMatrices(num, tools.MultiplyMatrixByScalar(X, c))
+		if k%2 == 0 {
+			den = tools.AddMatrices(den, tools.MultiplyMatrixByScalar(X, c))
+		} else {
+			den = tools.AddMatrices(den, tools.MultiplyMatrixByScalar(X, -c))
+		}
+	}
+
+	// D(A) при ||A|| <= 1/2 всегда невырождена: ||D(A) - I|| <= e^(1/2) - 1 < 1
+	inverse, err := equations.InverseMatrix(den)
+	if err != nil {
+		panic("matfunc: singular Pade denominator")
+	}
+	F := tools.MultiplyMatrices(inverse, num)
+
+	// Возведение в квадрат: e^A = (e^(A/2^j))^(2^j)
+	for k := 0; k < j; k++ {
+		F = tools.MultiplyMatrices(F, F)
+	}
+
+	return F
+}
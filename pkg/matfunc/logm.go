@@ -0,0 +1,75 @@
+package matfunc
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/node"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	logThreshold = 0.1 // допустимая норма A - I для аппроксимации Паде логарифма
+	logMaxRoots  = 64  // максимальное количество извлечений квадратного корня
+	logNodes     = 5   // число узлов Гаусса-Лежандра в аппроксимации Паде
+)
+
+// Logm вычисляет главный логарифм матрицы обратным методом масштабирования и возведения в квадрат
+// A - квадратная матрица без собственных значений на отрицательной вещественной полуоси
+// Корни A^(1/2^k) извлекаются до тех пор, пока ||A^(1/2^k) - I|| > 0.1, затем log(I + X)
+// вычисляется аппроксимацией Паде в виде суммы простых дробей, log A = 2^k log(A^(1/2^k))
+func Logm(A [][]float64) ([][]float64, error) {
+	return LogmContext(context.Background(), A)
+}
+
+// LogmContext работает как Logm, но проверяет ctx перед каждым извлечением квадратного корня и внутри него
+// При отмене ctx или истечении его срока вычисляет аппроксимацию Паде по последнему извлеченному корню
+// (менее точную, так как ||A^(1/2^k) - I|| еще больше 0.1) и возвращает ее вместе с ctx.Err()
+// Если аппроксимация по этому корню не определена, возвращает nil и ctx.Err()
+func LogmContext(ctx context.Context, A [][]float64) ([][]float64, error) {
+	N := len(A)
+	I := tools.IdentityMatrix(N)
+
+	k := 0
+	X := tools.AddMatrices(A, tools.MultiplyMatrixByScalar(I, -1))
+	var ctxErr error
+	for tools.MatrixNorm(X) > logThreshold {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		if k == logMaxRoots {
+			return nil, errors.New("matfunc: logarithm scaling did not converge")
+		}
+
+		root, err := SqrtmContext(ctx, A)
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		A = root
+		X = tools.AddMatrices(A, tools.MultiplyMatrixByScalar(I, -1))
+		k++
+	}
+
+	// log(I + X) = ∫[0,1] X (I + tX)⁻¹ dt ≈ Σ wj X (I + tj X)⁻¹
+	L := make([][]float64, N)
+	for i := range L {
+		L[i] = make([]float64, N)
+	}
+	for _, nd := range node.BuildGaussLegendreNodes(0, 1, logNodes) {
+		inverse, err := equations.InverseMatrix(tools.AddMatrices(I, tools.MultiplyMatrixByScalar(X, nd.X)))
+		if err != nil {
+			if ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, errors.New("matfunc: matrix logarithm is not defined")
+		}
+		L = tools.AddMatrices(L, tools.MultiplyMatrixByScalar(tools.MultiplyMatrices(X, inverse), nd.Y))
+	}
+
+	return tools.MultiplyMatrixByScalar(L, math.Ldexp(1, k)), ctxErr
+}
@@ -0,0 +1,143 @@
+package matfunc
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	e = 1e-10
+)
+
+var (
+	// S - симметричная положительно определенная матрица
+	S = [][]float64{
+		{4, 1, 0},
+		{1, 3, 1},
+		{0, 1, 2},
+	}
+	// B - несимметричная матрица с вещественными положительными собственными значениями
+	B = [][]float64{
+		{1, 2, 0},
+		{0, 3, 1},
+		{0, 0, 2},
+	}
+)
+
+// maxDifference максимальная по модулю разность элементов матриц
+func maxDifference(A, B [][]float64) float64 {
+	diff := 0.0
+	for i := range A {
+		diff = math.Max(diff, tools.MaxAbsoluteDifference(A[i], B[i]))
+	}
+
+	return diff
+}
+
+func TestExpm(t *testing.T) {
+	// e^(tJ) - матрица поворота на угол t
+	angle := 10.0
+	J := [][]float64{
+		{0, -angle},
+		{angle, 0},
+	}
+	R := [][]float64{
+		{math.Cos(angle), -math.Sin(angle)},
+		{math.Sin(angle), math.Cos(angle)},
+	}
+
+	if diff := maxDifference(Expm(J), R); diff > e {
+		t.Errorf("unexpected exponent, difference: %v", diff)
+	}
+
+	// Метод Якоби останавливается по сумме квадратов внедиагональных элементов, поэтому eps берется порядка ε²
+	F, err := FuncSymmetric(S, math.Exp, 1e-28)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := maxDifference(Expm(S), F); diff/tools.MatrixNorm(F) > 1e-12 {
+		t.Errorf("Expm and FuncSymmetric differ: %v", diff)
+	}
+}
+
+func TestSqrtm(t *testing.T) {
+	for _, A := range [][][]float64{S, B} {
+		X, err := Sqrtm(A)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := maxDifference(tools.MultiplyMatrices(X, X), A); diff > e {
+			t.Errorf("X*X != A, difference: %v", diff)
+		}
+	}
+
+	if _, err := Sqrtm([][]float64{{0, 0}, {0, 0}}); err == nil {
+		t.Errorf("expected error for singular matrix")
+	}
+}
+
+func TestInverseMatrix(t *testing.T) {
+	inverse, err := equations.InverseMatrix(B)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := maxDifference(tools.MultiplyMatrices(B, inverse), tools.IdentityMatrix(len(B))); diff > e {
+		t.Errorf("B*B⁻¹ != I, difference: %v", diff)
+	}
+
+	// Главный элемент второго шага порядка 1e-16 ниже порога N·ε·||A||: матрица численно вырождена
+	nearlySingular := [][]float64{
+		{1, 2},
+		{2, 4 + 4e-16},
+	}
+	if _, err := equations.InverseMatrix(nearlySingular); err != equations.ErrSingular {
+		t.Errorf("expected: %v, got: %v", equations.ErrSingular, err)
+	}
+}
+
+func TestLogm(t *testing.T) {
+	for _, A := range [][][]float64{S, B} {
+		L, err := Logm(A)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := maxDifference(Expm(L), A); diff > e {
+			t.Errorf("exp(log(A)) != A, difference: %v", diff)
+		}
+	}
+}
+
+func TestMatfuncContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Отмененный контекст прерывает итерации до первого шага: возвращаются начальные приближения
+	X, err := SqrtmContext(ctx, B)
+	if err != context.Canceled || maxDifference(X, B) != 0 {
+		t.Errorf("sqrtm: expected: %v, got: %v", context.Canceled, err)
+	}
+	if X[0][0]++; B[0][0] != 1 {
+		t.Errorf("sqrtm: result shares memory with the argument")
+	}
+
+	// После отмены ошибка контекста важнее ошибки области определения:
+	// у матрицы с отрицательным собственным значением логарифм не определен
+	if _, err := LogmContext(ctx, [][]float64{{-1, 0}, {0, 1}}); err != context.Canceled {
+		t.Errorf("logm: expected: %v, got: %v", context.Canceled, err)
+	}
+
+	L, err := LogmContext(ctx, B)
+	if err != context.Canceled || len(L) != len(B) {
+		t.Errorf("logm: expected: %v, got: %v", context.Canceled, err)
+	}
+
+	if _, err := LogmContext(context.Background(), B); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
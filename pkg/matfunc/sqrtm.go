@@ -0,0 +1,60 @@
+package matfunc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/foreverNP/calmet/pkg/equations"
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+const (
+	Kmax      = 100   // максимальное количество итераций
+	tolerance = 1e-13 // относительная точность итерационных процессов
+)
+
+// Sqrtm вычисляет главный квадратный корень матрицы итерационным методом Денмана-Биверса
+// A - квадратная матрица без собственных значений на отрицательной вещественной полуоси
+// Возвращает ошибку, если итерации вырождаются или не сходятся
+func Sqrtm(A [][]float64) ([][]float64, error) {
+	return SqrtmContext(context.Background(), A)
+}
+
+// SqrtmContext работает как Sqrtm, но проверяет ctx перед каждой итерацией
+// При отмене ctx или истечении его срока возвращает текущее приближение Y и ctx.Err()
+// Приближения не разделяют память с A, даже если отмена произошла до первой итерации
+func SqrtmContext(ctx context.Context, A [][]float64) ([][]float64, error) {
+	Y := make([][]float64, len(A))
+	for i := range A {
+		Y[i] = append([]float64(nil), A[i]...)
+	}
+	Z := tools.IdentityMatrix(len(A))
+
+	for K := 0; K < Kmax; K++ {
+		if err := ctx.Err(); err != nil {
+			return Y, err
+		}
+
+		Yinv, err := equations.InverseMatrix(Y)
+		if err != nil {
+			return nil, errors.New("matfunc: square root iteration is singular")
+		}
+		Zinv, err := equations.InverseMatrix(Z)
+		if err != nil {
+			return nil, errors.New("matfunc: square root iteration is singular")
+		}
+
+		// Y(k+1) = (Y(k) + Z(k)⁻¹) / 2, Z(k+1) = (Z(k) + Y(k)⁻¹) / 2
+		Yn := tools.MultiplyMatrixByScalar(tools.AddMatrices(Y, Zinv), 0.5)
+		Zn := tools.MultiplyMatrixByScalar(tools.AddMatrices(Z, Yinv), 0.5)
+
+		diff := tools.MatrixNorm(tools.AddMatrices(Yn, tools.MultiplyMatrixByScalar(Y, -1)))
+		Y, Z = Yn, Zn
+
+		if diff <= tolerance*tools.MatrixNorm(Y) {
+			return Y, nil
+		}
+	}
+
+	return nil, errors.New("matfunc: square root iteration did not converge")
+}
@@ -0,0 +1,22 @@
+package matfunc
+
+import (
+	"github.com/foreverNP/calmet/pkg/eigen"
+)
+
+// FuncSymmetric вычисляет функцию f(A) симметричной матрицы через спектральное разложение
+// f(A) = Σ f(λi) xi xiᵀ, где λi, xi - собственные пары, найденные методом Якоби
+// A - симметричная матрица, f - скалярная функция, eps - точность метода Якоби
+// Возвращает ошибку, если матрица не симметричная
+func FuncSymmetric(A [][]float64, f func(float64) float64, eps float64) ([][]float64, error) {
+	d, err := eigen.Decompose(A, eps)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range d.Pairs {
+		d.Pairs[i].Value = f(d.Pairs[i].Value)
+	}
+
+	return d.Reconstruct(), nil
+}
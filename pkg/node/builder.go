@@ -39,15 +39,16 @@ func BuildChebyshevNodes(f func(float64) float64, a float64, b float64, n int) [
 }
 
 // BuildGaussLegendreNodes строит узлы и веса для квадратурной формулы Гаусса-Лежандра
-// a и b - границы интервала, n - число узлов
+// a и b - границы интервала, n - число узлов (любое положительное)
+// Возвращает узлы в порядке возрастания X, Y - веса
 func BuildGaussLegendreNodes(a, b float64, n int) []Node {
-	if _, ok := gaussLegendreNodes[n]; !ok {
-		panic("integral: unknown number of nodes for Gauss-Legendre quadrature")
+	if n < 1 {
+		panic("node: number of nodes for Gauss-Legendre quadrature must be positive")
 	}
 
 	nodes := make([]Node, n)
 
-	copy(nodes, gaussLegendreNodes[n])
+	copy(nodes, gaussLegendreNodes(n))
 
 	for i := range nodes {
 		nodes[i].X = (a+b)/2.0 + (b-a)/2.0*nodes[i].X
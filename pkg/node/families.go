@@ -0,0 +1,163 @@
+package node
+
+import (
+	"math"
+)
+
+// BuildGaussChebyshevNodes строит узлы и веса квадратурной формулы Гаусса-Чебышева для интеграла
+// ∫[a,b] f(x) / sqrt((x-a)(b-x)) dx, a и b - границы интервала, n - число узлов
+// Возвращает узлы в порядке возрастания X, Y - веса (все равны π/n)
+func BuildGaussChebyshevNodes(a, b float64, n int) []Node {
+	if n < 1 {
+		panic("node: number of nodes must be positive")
+	}
+
+	nodes := make([]Node, n)
+	for i := 0; i < n; i++ {
+		t := -math.Cos(math.Pi * (2*float64(i) + 1) / (2 * float64(n)))
+		nodes[i] = Node{X: (a+b)/2 + (b-a)/2*t, Y: math.Pi / float64(n)}
+	}
+
+	return nodes
+}
+
+// BuildGaussJacobiNodes строит узлы и веса квадратурной формулы Гаусса-Якоби для интеграла
+// ∫[-1,1] f(x) (1-x)^alpha (1+x)^beta dx, alpha, beta > -1 - показатели весовой функции, n - число узлов
+// Возвращает узлы в порядке возрастания X, Y - веса
+func BuildGaussJacobiNodes(alpha, beta float64, n int) []Node {
+	if n < 1 {
+		panic("node: number of nodes must be positive")
+	}
+	if alpha <= -1 || beta <= -1 {
+		panic("node: Jacobi weight exponents must be greater than -1")
+	}
+
+	a := make([]float64, n)
+	b := make([]float64, n)
+	s := alpha + beta
+
+	// b[0] = ∫ (1-x)^alpha (1+x)^beta dx = 2^(s+1) Γ(alpha+1) Γ(beta+1) / Γ(s+2)
+	lg1, _ := math.Lgamma(alpha + 1)
+	lg2, _ := math.Lgamma(beta + 1)
+	lg3, _ := math.Lgamma(s + 2)
+	b[0] = math.Exp((s+1)*math.Ln2 + lg1 + lg2 - lg3)
+	a[0] = (beta - alpha) / (s + 2)
+
+	for k := 1; k < n; k++ {
+		fk := float64(k)
+		d := 2*fk + s
+
+		a[k] = (beta*beta - alpha*alpha) / (d * (d + 2))
+		if k == 1 {
+			// Сокращенная форма, пригодная и при alpha + beta = -1
+			b[k] = 4 * (1 + alpha) * (1 + beta) / ((2 + s) * (2 + s) * (3 + s))
+		} else {
+			b[k] = 4 * fk * (fk + alpha) * (fk + beta) * (fk + s) / (d * d * (d + 1) * (d - 1))
+		}
+	}
+
+	return BuildGaussNodes(a, b)
+}
+
+// BuildGaussLaguerreNodes строит узлы и веса обобщенной квадратурной формулы Гаусса-Лагерра для интеграла
+// ∫[0,∞) f(x) x^alpha e^(-x) dx, alpha > -1 - показатель весовой функции, n - число узлов
+// Возвращает узлы в порядке возрастания X, Y - веса
+func BuildGaussLaguerreNodes(alpha float64, n int) []Node {
+	if n < 1 {
+		panic("node: number of nodes must be positive")
+	}
+	if alpha <= -1 {
+		panic("node: Laguerre weight exponent must be greater than -1")
+	}
+
+	a := make([]float64, n)
+	b := make([]float64, n)
+
+	lg, _ := math.Lgamma(alpha + 1)
+	b[0] = math.Exp(lg)
+	for k := 0; k < n; k++ {
+		fk := float64(k)
+		a[k] = 2*fk + alpha + 1
+		if k > 0 {
+			b[k] = fk * (fk + alpha)
+		}
+	}
+
+	return BuildGaussNodes(a, b)
+}
+
+// BuildGaussHermiteNodes строит узлы и веса квадратурной формулы Гаусса-Эрмита для интеграла
+// ∫(-∞,∞) f(x) e^(-x²) dx, n - число узлов
+// Возвращает узлы в порядке возрастания X, Y - веса
+func BuildGaussHermiteNodes(n int) []Node {
+	if n < 1 {
+		panic("node: number of nodes must be positive")
+	}
+
+	a := make([]float64, n)
+	b := make([]float64, n)
+
+	b[0] = math.Sqrt(math.Pi)
+	for k := 1; k < n; k++ {
+		b[k] = float64(k) / 2
+	}
+
+	return BuildGaussNodes(a, b)
+}
+
+// BuildGaussLobattoNodes строит узлы и веса квадратурной формулы Гаусса-Лобатто, включающей оба конца интервала
+// a и b - границы интервала, n >= 2 - число узлов
+// Внутренние узлы - корни P'(n-1), т.е. узлы Гаусса-Якоби с alpha = beta = 1, веса 2 / (n(n-1) P(n-1)(x)²)
+// Формула точна для многочленов степени 2n-3. Возвращает узлы в порядке возрастания X, Y - веса
+func BuildGaussLobattoNodes(a, b float64, n int) []Node {
+	if n < 2 {
+		panic("node: Gauss-Lobatto quadrature requires at least two nodes")
+	}
+
+	nodes := make([]Node, n)
+	endWeight := 2 / float64(n*(n-1))
+	nodes[0] = Node{X: -1, Y: endWeight}
+	nodes[n-1] = Node{X: 1, Y: endWeight}
+
+	if n > 2 {
+		for i, nd := range BuildGaussJacobiNodes(1, 1, n-2) {
+			p, _ := legendre(n-1, nd.X)
+			nodes[i+1] = Node{X: nd.X, Y: endWeight / (p * p)}
+		}
+	}
+
+	return mapNodes(nodes, a, b)
+}
+
+// BuildGaussRadauNodes строит узлы и веса квадратурной формулы Гаусса-Радо, включающей левый конец интервала
+// a и b - границы интервала, n - число узлов
+// Внутренние узлы - узлы Гаусса-Якоби с alpha = 0, beta = 1, веса (1-x) / (n² P(n-1)(x)²)
+// Формула точна для многочленов степени 2n-2. Возвращает узлы в порядке возрастания X, Y - веса
+func BuildGaussRadauNodes(a, b float64, n int) []Node {
+	if n < 1 {
+		panic("node: number of nodes must be positive")
+	}
+
+	nodes := make([]Node, n)
+	nn := float64(n * n)
+	nodes[0] = Node{X: -1, Y: 2 / nn}
+
+	if n > 1 {
+		for i, nd := range BuildGaussJacobiNodes(0, 1, n-1) {
+			p, _ := legendre(n-1, nd.X)
+			nodes[i+1] = Node{X: nd.X, Y: (1 - nd.X) / (nn * p * p)}
+		}
+	}
+
+	return mapNodes(nodes, a, b)
+}
+
+// mapNodes отображает узлы и веса с отрезка [-1, 1] на [a, b]
+func mapNodes(nodes []Node, a, b float64) []Node {
+	for i := range nodes {
+		nodes[i].X = (a+b)/2.0 + (b-a)/2.0*nodes[i].X
+		nodes[i].Y *= (b - a) / 2.0
+	}
+
+	return nodes
+}
@@ -0,0 +1,82 @@
+package node
+
+import (
+	"math"
+
+	"github.com/foreverNP/calmet/pkg/tools"
+)
+
+// BuildGaussNodes строит узлы и веса квадратурной формулы Гаусса по коэффициентам трехчленного рекуррентного
+// соотношения для монических ортогональных многочленов p(k+1)(x) = (x - alpha[k]) p(k)(x) - beta[k] p(k-1)(x)
+// alpha, beta - коэффициенты (n элементов), beta[0] - интеграл от весовой функции
+// Узлы - собственные значения матрицы Якоби (alpha на диагонали, sqrt(beta[k]) вне диагонали), вычисляемые
+// бисекцией по последовательности Штурма, веса - числа Кристоффеля 1 / Σ πk(x)² для ортонормированных многочленов
+// Возвращает узлы в порядке возрастания X, Y - веса
+func BuildGaussNodes(alpha, beta []float64) []Node {
+	n := len(alpha)
+	if n < 1 || len(beta) != n {
+		panic("node: recurrence coefficients must have the same positive length")
+	}
+
+	off := make([]float64, n-1)
+	for k := 1; k < n; k++ {
+		if beta[k] <= 0 {
+			panic("node: recurrence coefficients beta must be positive")
+		}
+		off[k-1] = math.Sqrt(beta[k])
+	}
+
+	nodes := make([]Node, n)
+	for i, x := range tools.BisectionIndices(alpha, off, 0, n-1, 0) {
+		// Ортонормированные многочлены: sqrt(beta[k+1]) π(k+1) = (x - alpha[k]) πk - sqrt(beta[k]) π(k-1)
+		prev, cur := 0.0, 1/math.Sqrt(beta[0])
+		sum := cur * cur
+		for k := 0; k < n-1; k++ {
+			next := (x - alpha[k]) * cur
+			if k > 0 {
+				next -= off[k-1] * prev
+			}
+			prev, cur = cur, next/off[k]
+			sum += cur * cur
+		}
+
+		nodes[i] = Node{X: x, Y: 1 / sum}
+	}
+
+	return nodes
+}
+
+// BuildGaussNodesFromMoments строит узлы и веса квадратурной формулы Гаусса с n = len(moments)/2 узлами
+// по моментам весовой функции moments[k] = ∫ x^k w(x) dx, k = 0..2n-1
+// Коэффициенты рекуррентного соотношения вычисляются алгоритмом Чебышева; задача плохо обусловлена,
+// поэтому метод пригоден лишь для небольших n
+func BuildGaussNodesFromMoments(moments []float64) []Node {
+	n := len(moments) / 2
+	if n < 1 {
+		panic("node: at least two moments are required")
+	}
+
+	alpha := make([]float64, n)
+	beta := make([]float64, n)
+
+	// sigma[k][l] = ∫ p(k)(x) x^l w(x) dx, храним две предыдущие строки
+	prev := make([]float64, 2*n)
+	cur := make([]float64, 2*n)
+	copy(cur, moments[:2*n])
+
+	alpha[0] = moments[1] / moments[0]
+	beta[0] = moments[0]
+
+	for k := 1; k < n; k++ {
+		next := make([]float64, 2*n)
+		for l := k; l < 2*n-k; l++ {
+			next[l] = cur[l+1] - alpha[k-1]*cur[l] - beta[k-1]*prev[l]
+		}
+
+		alpha[k] = next[k+1]/next[k] - cur[k]/cur[k-1]
+		beta[k] = next[k] / cur[k-1]
+		prev, cur = cur, next
+	}
+
+	return BuildGaussNodes(alpha, beta)
+}
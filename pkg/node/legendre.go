@@ -0,0 +1,89 @@
+package node
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	legendreMaxIter = 100   // максимальное количество итераций метода Ньютона
+	legendreEps     = 1e-15 // точность вычисления корней многочлена Лежандра
+)
+
+// Кэш узлов и весов квадратурной формулы Гаусса-Лежандра на [-1, 1] по числу узлов
+var (
+	gaussLegendreMu    sync.RWMutex
+	gaussLegendreCache = map[int][]Node{}
+)
+
+// legendre вычисляет значение многочлена Лежандра Pn(x) и его производной по трехчленному рекуррентному соотношению
+func legendre(n int, x float64) (float64, float64) {
+	p0, p1 := 1.0, x
+	if n == 0 {
+		return 1, 0
+	}
+
+	for k := 2; k <= n; k++ {
+		p0, p1 = p1, (float64(2*k-1)*x*p1-float64(k-1)*p0)/float64(k)
+	}
+
+	return p1, float64(n) * (x*p1 - p0) / (x*x - 1)
+}
+
+// computeGaussLegendreNodes вычисляет узлы (корни Pn) и веса формулы Гаусса-Лежандра на [-1, 1]
+// методом Ньютона с начальными приближениями x = cos(π(i + 3/4) / (n + 1/2))
+func computeGaussLegendreNodes(n int) []Node {
+	nodes := make([]Node, n)
+
+	// Корни симметричны относительно нуля, поэтому вычисляем только неотрицательные
+	for i := 0; i < (n+1)/2; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		_, dp := legendre(n, x)
+
+		for iter := 0; iter < legendreMaxIter; iter++ {
+			var p float64
+			p, dp = legendre(n, x)
+			dx := p / dp
+			x -= dx
+
+			if math.Abs(dx) <= legendreEps {
+				_, dp = legendre(n, x)
+				break
+			}
+		}
+
+		w := 2 / ((1 - x*x) * dp * dp)
+		nodes[i] = Node{X: -x, Y: w}
+		nodes[n-1-i] = Node{X: x, Y: w}
+	}
+
+	// Центральный узел при нечетном n
+	if n%2 == 1 {
+		nodes[n/2].X = 0
+	}
+
+	return nodes
+}
+
+// gaussLegendreNodes возвращает узлы и веса формулы Гаусса-Лежандра на [-1, 1], вычисляя их при первом обращении
+// Безопасна для одновременного вызова из нескольких горутин; возвращаемый слайс изменять нельзя
+func gaussLegendreNodes(n int) []Node {
+	gaussLegendreMu.RLock()
+	nodes, ok := gaussLegendreCache[n]
+	gaussLegendreMu.RUnlock()
+	if ok {
+		return nodes
+	}
+
+	nodes = computeGaussLegendreNodes(n)
+
+	gaussLegendreMu.Lock()
+	if cached, ok := gaussLegendreCache[n]; ok {
+		nodes = cached
+	} else {
+		gaussLegendreCache[n] = nodes
+	}
+	gaussLegendreMu.Unlock()
+
+	return nodes
+}
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"math"
+	"testing"
+)
+
+const (
+	e = 1e-12
+)
+
+// quadrature вычисляет Σ wi f(xi)
+func quadrature(nodes []Node, f func(float64) float64) float64 {
+	sum := 0.0
+	for _, nd := range nodes {
+		sum += nd.Y * f(nd.X)
+	}
+
+	return sum
+}
+
+func TestGaussFamilies(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []Node
+		f     func(float64) float64
+		exact float64
+	}{
+		// Многочлены степени 2n-1 интегрируются точно
+		{"Legendre", BuildGaussLegendreNodes(-1, 1, 6), func(x float64) float64 { return math.Pow(x, 10) }, 2.0 / 11},
+		{"Chebyshev", BuildGaussChebyshevNodes(-1, 1, 4), func(x float64) float64 { return math.Pow(x, 6) }, 5 * math.Pi / 16},
+		{"Jacobi(0,0)", BuildGaussJacobiNodes(0, 0, 6), func(x float64) float64 { return math.Pow(x, 10) }, 2.0 / 11},
+		{"Jacobi(1,0)", BuildGaussJacobiNodes(1, 0, 3), func(x float64) float64 { return x * x }, 2.0 / 3},
+		{"Jacobi(-0.5,-0.5)", BuildGaussJacobiNodes(-0.5, -0.5, 4), func(x float64) float64 { return math.Pow(x, 6) }, 5 * math.Pi / 16},
+		{"Laguerre", BuildGaussLaguerreNodes(0, 8), func(x float64) float64 { return math.Pow(x, 15) }, 1307674368000},
+		{"Laguerre(0.5)", BuildGaussLaguerreNodes(0.5, 5), func(x float64) float64 { return x * x * x }, math.Gamma(4.5)},
+		{"Hermite", BuildGaussHermiteNodes(7), func(x float64) float64 { return math.Pow(x, 12) }, 10395 * math.Sqrt(math.Pi) / 64},
+		// Лобатто точна для степени 2n-3, Радо - для 2n-2
+		{"Lobatto", BuildGaussLobattoNodes(0, 2, 6), func(x float64) float64 { return math.Pow(x, 9) }, 102.4},
+		{"Radau", BuildGaussRadauNodes(0, 2, 5), func(x float64) float64 { return math.Pow(x, 8) }, 512.0 / 9},
+		// ∫[-1,1] x^4 dx через моменты весовой функции 1
+		{"Moments", BuildGaussNodesFromMoments([]float64{2, 0, 2.0 / 3, 0, 2.0 / 5, 0}), func(x float64) float64 { return math.Pow(x, 4) }, 2.0 / 5},
+	}
+
+	for _, test := range tests {
+		result := quadrature(test.nodes, test.f)
+		if math.Abs(result-test.exact) > e*math.Max(1, math.Abs(test.exact)) {
+			t.Errorf("%s: expected: %v, got: %v", test.name, test.exact, result)
+		}
+
+		for i := 1; i < len(test.nodes); i++ {
+			if test.nodes[i].X <= test.nodes[i-1].X {
+				t.Errorf("%s: nodes are not in ascending order", test.name)
+			}
+		}
+	}
+
+	lobatto := BuildGaussLobattoNodes(0, 2, 4)
+	if lobatto[0].X != 0 || lobatto[3].X != 2 {
+		t.Errorf("Lobatto nodes must include endpoints: %v", lobatto)
+	}
+	if radau := BuildGaussRadauNodes(0, 2, 4); radau[0].X != 0 {
+		t.Errorf("Radau nodes must include the left endpoint: %v", radau)
+	}
+}
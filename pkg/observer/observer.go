@@ -0,0 +1,109 @@
+package observer
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+)
+
+// Step описывает одну итерацию численного метода
+type Step struct {
+	H        float64 // Шаг сетки (0 для методов без сетки).
+	Estimate float64 // Текущее приближение (для векторных методов - его равномерная норма).
+	Error    float64 // Оценка погрешности (по Рунге, разности приближений или невязке).
+	N        int     // Количество отрезков или номер итерации.
+}
+
+// Observer получает сведения о каждой итерации численного метода
+// Методы вызывают Observe последовательно из той горутины, в которой они выполняются
+type Observer interface {
+	Observe(s Step)
+}
+
+// Func позволяет использовать обычную функцию в качестве Observer
+type Func func(s Step)
+
+// Observe вызывает f(s)
+func (f Func) Observe(s Step) {
+	f(s)
+}
+
+// Recorder сохраняет все полученные итерации, например, для проверки в тестах
+type Recorder struct {
+	Steps []Step
+}
+
+// Observe добавляет итерацию s к списку
+func (r *Recorder) Observe(s Step) {
+	r.Steps = append(r.Steps, s)
+}
+
+// Writer печатает каждую итерацию строкой вида "h = ..., Q = ..., R = ..., n = ..."
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter создает Observer, печатающий итерации в w (файл, буфер, стандартный вывод)
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Observe печатает итерацию s
+func (o *Writer) Observe(s Step) {
+	fmt.Fprintf(o.w, "h = %.10f, Q = %.10f, R = %.10f, n = %d\n", s.H, s.Estimate, s.Error, s.N)
+}
+
+// Slog передает каждую итерацию в структурированный журнал slog
+type Slog struct {
+	logger *slog.Logger
+	level  slog.Level
+	msg    string
+}
+
+// NewSlog создает Observer, записывающий итерации в logger на уровне level с сообщением msg
+// и атрибутами h, q, r, n
+func NewSlog(logger *slog.Logger, level slog.Level, msg string) *Slog {
+	return &Slog{logger: logger, level: level, msg: msg}
+}
+
+// Observe записывает итерацию s в журнал
+func (o *Slog) Observe(s Step) {
+	o.logger.LogAttrs(context.Background(), o.level, o.msg,
+		slog.Float64("h", s.H), slog.Float64("q", s.Estimate), slog.Float64("r", s.Error), slog.Int("n", s.N))
+}
+
+// CSV записывает итерации в формате CSV со строкой заголовка h,q,r,n
+type CSV struct {
+	w      *csv.Writer
+	header bool
+}
+
+// NewCSV создает Observer, записывающий итерации в w в формате CSV
+// Каждая строка записывается сразу, ошибку записи можно получить методом Err
+func NewCSV(w io.Writer) *CSV {
+	return &CSV{w: csv.NewWriter(w)}
+}
+
+// Observe записывает итерацию s строкой CSV (перед первой строкой записывается заголовок)
+func (o *CSV) Observe(s Step) {
+	if !o.header {
+		o.w.Write([]string{"h", "q", "r", "n"})
+		o.header = true
+	}
+
+	o.w.Write([]string{
+		strconv.FormatFloat(s.H, 'g', -1, 64),
+		strconv.FormatFloat(s.Estimate, 'g', -1, 64),
+		strconv.FormatFloat(s.Error, 'g', -1, 64),
+		strconv.Itoa(s.N),
+	})
+	o.w.Flush()
+}
+
+// Err возвращает первую ошибку записи, если она произошла
+func (o *CSV) Err() error {
+	return o.w.Error()
+}
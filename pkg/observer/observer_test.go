@@ -0,0 +1,68 @@
+package observer
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+var steps = []Step{
+	{H: 0.5, Estimate: 1.25, Error: 0.125, N: 2},
+	{H: 0.25, Estimate: 1.5, Error: 1e-9, N: 4},
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, s := range steps {
+		w.Observe(s)
+	}
+
+	expected := "h = 0.5000000000, Q = 1.2500000000, R = 0.1250000000, n = 2\n" +
+		"h = 0.2500000000, Q = 1.5000000000, R = 0.0000000010, n = 4\n"
+	if buf.String() != expected {
+		t.Errorf("expected: %q, got: %q", expected, buf.String())
+	}
+}
+
+func TestCSV(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewCSV(&buf)
+	for _, s := range steps {
+		c.Observe(s)
+	}
+
+	expected := "h,q,r,n\n0.5,1.25,0.125,2\n0.25,1.5,1e-09,4\n"
+	if buf.String() != expected || c.Err() != nil {
+		t.Errorf("expected: %q, got: %q (%v)", expected, buf.String(), c.Err())
+	}
+}
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	o := NewSlog(logger, slog.LevelDebug, "integral step")
+	o.Observe(steps[0])
+
+	for _, part := range []string{"level=DEBUG", `msg="integral step"`, "h=0.5", "q=1.25", "r=0.125", "n=2"} {
+		if !strings.Contains(buf.String(), part) {
+			t.Errorf("expected %q in %q", part, buf.String())
+		}
+	}
+}
+
+func TestFuncAndRecorder(t *testing.T) {
+	var r Recorder
+	count := 0
+	observers := []Observer{&r, Func(func(Step) { count++ })}
+	for _, s := range steps {
+		for _, o := range observers {
+			o.Observe(s)
+		}
+	}
+
+	if len(r.Steps) != 2 || r.Steps[1] != steps[1] || count != 2 {
+		t.Errorf("expected: %v, got: %v (%d calls)", steps, r.Steps, count)
+	}
+}
@@ -80,3 +80,64 @@ func (cs CubicSpline) Solve(x float64) (float64, error) {
 
 	return result, nil
 }
+
+// interval возвращает номер i отрезка [x(i-1), x(i)], содержащего x, или ошибку, если x вне отрезка интерполяции.
+func (cs CubicSpline) interval(x float64) (int, error) {
+	if x < cs.nodes[0].X || x > cs.nodes[len(cs.nodes)-1].X {
+		return 0, errors.New("the argument is out of range")
+	}
+
+	for k := 1; k < len(cs.nodes)-1; k++ {
+		if x <= cs.nodes[k].X {
+			return k, nil
+		}
+	}
+
+	return len(cs.nodes) - 1, nil
+}
+
+// CumulativeIntegral вычисляет интегралы сплайна от первого узла до каждого из узлов.
+// Интеграл по отрезку [x(i-1), x(i)] равен h(y(i-1) + y(i))/2 - h³(M(i-1) + M(i))/24, где M - вторые производные.
+func (cs CubicSpline) CumulativeIntegral() []float64 {
+	result := make([]float64, len(cs.nodes))
+	for i := 1; i < len(cs.nodes); i++ {
+		h := cs.nodes[i].X - cs.nodes[i-1].X
+		result[i] = result[i-1] + h*(cs.nodes[i-1].Y+cs.nodes[i].Y)/2 - h*h*h*(cs.coeffs[i-1]+cs.coeffs[i])/24
+	}
+
+	return result
+}
+
+// Integrate вычисляет интеграл сплайна от a до b точно (как интеграл кусочного многочлена).
+// Возвращает ошибку, если a или b вне отрезка интерполяции.
+func (cs CubicSpline) Integrate(a, b float64) (float64, error) {
+	cumulative := cs.CumulativeIntegral()
+
+	// Первообразная, равная нулю в первом узле
+	antiderivative := func(x float64) (float64, error) {
+		i, err := cs.interval(x)
+		if err != nil {
+			return 0, err
+		}
+
+		h := cs.nodes[i].X - cs.nodes[i-1].X
+		p, q := x-cs.nodes[i-1].X, cs.nodes[i].X-x
+		left := cs.nodes[i-1].Y - h*h/6*cs.coeffs[i-1]
+		right := cs.nodes[i].Y - h*h/6*cs.coeffs[i]
+
+		return cumulative[i-1] +
+			cs.coeffs[i-1]*(h*h*h*h-q*q*q*q)/(24*h) + cs.coeffs[i]*p*p*p*p/(24*h) +
+			left*(h*h-q*q)/(2*h) + right*p*p/(2*h), nil
+	}
+
+	fa, err := antiderivative(a)
+	if err != nil {
+		return 0, err
+	}
+	fb, err := antiderivative(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return fb - fa, nil
+}
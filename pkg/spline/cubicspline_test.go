@@ -47,3 +47,22 @@ func TestCubicSpline_Solve(t *testing.T) {
 		t.Errorf("unexpected error: %v", interErr)
 	}
 }
+
+func TestCubicSpline_Integrate(t *testing.T) {
+	spl := New(Df, node.BuildEquidistantNodes(f, a, b, N))
+
+	// ∫ sin x dx от 0 до 2 = 1 - cos 2
+	result, err := spl.Integrate(0, 2)
+	if err != nil || math.Abs(result-(1-math.Cos(2))) > e {
+		t.Errorf("expected: %v, got: %v (%v)", 1-math.Cos(2), result, err)
+	}
+
+	cumulative := spl.CumulativeIntegral()
+	if full, _ := spl.Integrate(a, b); math.Abs(cumulative[N]-full) > 1e-12 || math.Abs(full) > e {
+		t.Errorf("expected: %v, got: %v and %v", 0, cumulative[N], full)
+	}
+
+	if _, err := spl.Integrate(a-1, b); err == nil {
+		t.Errorf("expected an error for the argument out of range")
+	}
+}
@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"math"
+)
+
+const (
+	bisectionMaxIter = 200                   // максимальное количество шагов бисекции для одного собственного значения
+	machEps          = 2.220446049250313e-16 // машинный эпсилон для float64
+)
+
+// GershgorinBounds Границы спектра симметричной трехдиагональной матрицы по кругам Гершгорина
+// d - главная диагональ (n элементов), e - побочная диагональ (n-1 элемент)
+// Возвращает отрезок [lo, hi], содержащий все собственные значения
+func GershgorinBounds(d, e []float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+
+	for i := range d {
+		r := 0.0
+		if i > 0 {
+			r += math.Abs(e[i-1])
+		}
+		if i < len(e) {
+			r += math.Abs(e[i])
+		}
+
+		lo = math.Min(lo, d[i]-r)
+		hi = math.Max(hi, d[i]+r)
+	}
+
+	return lo, hi
+}
+
+// SturmCount Число перемен знака последовательности Штурма
+// d - главная диагональ, e - побочная диагональ, x - точка
+// Возвращает количество собственных значений симметричной трехдиагональной матрицы, меньших x
+func SturmCount(d, e []float64, x float64) int {
+	count := 0
+	q := 1.0
+
+	for i := range d {
+		if i == 0 {
+			q = d[0] - x
+		} else {
+			q = d[i] - x - e[i-1]*e[i-1]/q
+		}
+
+		// Нулевой элемент последовательности заменяем малым числом, чтобы избежать деления на ноль
+		if q == 0 {
+			q = -machEps * (math.Abs(x) + 1)
+		}
+		if q < 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// BisectionIndices Собственные значения с номерами от i до j включительно (в порядке возрастания, с нуля)
+// симметричной трехдиагональной матрицы методом бисекции
+// d - главная диагональ, e - побочная диагональ, eps - точность
+func BisectionIndices(d, e []float64, i, j int, eps float64) []float64 {
+	if i < 0 || j >= len(d) || i > j {
+		panic("Номера собственных значений вне допустимого диапазона")
+	}
+
+	lo, hi := GershgorinBounds(d, e)
+
+	eigenvalues := make([]float64, 0, j-i+1)
+	for k := i; k <= j; k++ {
+		eigenvalues = append(eigenvalues, bisect(d, e, k, lo, hi, eps))
+	}
+
+	return eigenvalues
+}
+
+// BisectionInterval Собственные значения из полуинтервала [a, b) симметричной трехдиагональной матрицы
+// методом бисекции
+// d - главная диагональ, e - побочная диагональ, eps - точность
+// Возвращает собственные значения в порядке возрастания
+func BisectionInterval(d, e []float64, a, b, eps float64) []float64 {
+	lo, hi := GershgorinBounds(d, e)
+	lo, hi = math.Max(lo, a), math.Min(hi, b)
+	if lo >= hi {
+		return []float64{}
+	}
+
+	// Номера собственных значений, попадающих в интервал, определяем по числу перемен знака
+	first, last := SturmCount(d, e, a), SturmCount(d, e, b)
+
+	eigenvalues := make([]float64, 0, last-first)
+	for k := first; k < last; k++ {
+		eigenvalues = append(eigenvalues, bisect(d, e, k, lo, hi, eps))
+	}
+
+	return eigenvalues
+}
+
+// bisect находит k-е по возрастанию собственное значение на отрезке [lo, hi]
+func bisect(d, e []float64, k int, lo, hi, eps float64) float64 {
+	for iter := 0; iter < bisectionMaxIter && hi-lo > eps; iter++ {
+		mid := (lo + hi) / 2
+		if mid == lo || mid == hi {
+			break
+		}
+
+		if SturmCount(d, e, mid) > k {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return (lo + hi) / 2
+}
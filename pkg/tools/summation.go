@@ -0,0 +1,144 @@
+package tools
+
+import "math"
+
+// Summation определяет способ суммирования чисел с плавающей точкой
+// Нулевое значение - NeumaierSum, способ по умолчанию
+type Summation int
+
+const (
+	NeumaierSum Summation = iota // суммирование Кахана-Бабушки-Ноймайера, погрешность O(ε) и при разных знаках
+	NaiveSum                     // последовательное суммирование, погрешность растет как O(n·ε)
+	KahanSum                     // компенсированное суммирование Кахана, погрешность O(ε) при слагаемых одного знака
+	PairwiseSum                  // попарное (каскадное) суммирование, погрешность O(ε·log n)
+)
+
+// pairwiseBlock количество слагаемых, которые при попарном суммировании складываются последовательно
+const pairwiseBlock = 16
+
+// Accumulator накапливает сумму чисел по мере их поступления
+// Нулевое значение готово к использованию, соответствует сумме 0 и суммирует методом Кахана-Бабушки-Ноймайера
+type Accumulator struct {
+	sum          float64   // Текущая сумма.
+	compensation float64   // Накопленная погрешность округления.
+	method       Summation // Способ суммирования.
+}
+
+// NewAccumulator возвращает пустую сумму, накапливаемую способом s
+// Попарное суммирование требует всех слагаемых сразу, поэтому для PairwiseSum используется NeumaierSum
+func NewAccumulator(s Summation) Accumulator {
+	if s < NeumaierSum || s > PairwiseSum {
+		panic("tools: unknown summation method")
+	}
+
+	return Accumulator{method: s}
+}
+
+// Add прибавляет x к сумме
+func (a *Accumulator) Add(x float64) {
+	switch a.method {
+	case NaiveSum:
+		a.sum += x
+	case KahanSum:
+		y := x - a.compensation
+		t := a.sum + y
+		a.compensation = (t - a.sum) - y
+		a.sum = t
+	default:
+		t := a.sum + x
+		if math.Abs(a.sum) >= math.Abs(x) {
+			a.compensation += (a.sum - t) + x
+		} else {
+			a.compensation += (x - t) + a.sum
+		}
+		a.sum = t
+	}
+}
+
+// Sum возвращает накопленную сумму с учетом поправки
+func (a *Accumulator) Sum() float64 {
+	if a.method == NaiveSum || a.method == KahanSum {
+		return a.sum
+	}
+
+	return a.sum + a.compensation
+}
+
+// SumKahan Сумма элементов вектора методом компенсированного суммирования Кахана
+func SumKahan(vector []float64) float64 {
+	return sumTerms(len(vector), func(i int) float64 { return vector[i] }, KahanSum)
+}
+
+// SumNeumaier Сумма элементов вектора методом Кахана-Бабушки-Ноймайера
+func SumNeumaier(vector []float64) float64 {
+	return sumTerms(len(vector), func(i int) float64 { return vector[i] }, NeumaierSum)
+}
+
+// SumPairwise Сумма элементов вектора попарным суммированием
+func SumPairwise(vector []float64) float64 {
+	return sumTerms(len(vector), func(i int) float64 { return vector[i] }, PairwiseSum)
+}
+
+// Sum Сумма элементов вектора способом s
+func Sum(vector []float64, s Summation) float64 {
+	return sumTerms(len(vector), func(i int) float64 { return vector[i] }, s)
+}
+
+// DotProductSum Скалярное произведение двух векторов, произведения складываются способом s
+func DotProductSum(vector1, vector2 []float64, s Summation) float64 {
+	if len(vector1) != len(vector2) {
+		panic("Длины векторов должны совпадать")
+	}
+
+	return sumTerms(len(vector1), func(i int) float64 { return vector1[i] * vector2[i] }, s)
+}
+
+// EuclideanNormSum Евклидова норма вектора, квадраты элементов складываются способом s
+func EuclideanNormSum(vector []float64, s Summation) float64 {
+	return math.Sqrt(sumTerms(len(vector), func(i int) float64 { return vector[i] * vector[i] }, s))
+}
+
+// sumTerms вычисляет сумму слагаемых term(0), ..., term(n-1) способом s
+func sumTerms(n int, term func(i int) float64, s Summation) float64 {
+	switch s {
+	case NaiveSum:
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += term(i)
+		}
+		return sum
+	case KahanSum:
+		sum, c := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			y := term(i) - c
+			t := sum + y
+			c = (t - sum) - y
+			sum = t
+		}
+		return sum
+	case NeumaierSum:
+		var acc Accumulator
+		for i := 0; i < n; i++ {
+			acc.Add(term(i))
+		}
+		return acc.Sum()
+	case PairwiseSum:
+		return pairwiseSum(0, n, term)
+	default:
+		panic("tools: unknown summation method")
+	}
+}
+
+// pairwiseSum вычисляет сумму слагаемых term(lo), ..., term(hi-1), рекурсивно деля диапазон пополам
+func pairwiseSum(lo, hi int, term func(i int) float64) float64 {
+	if hi-lo <= pairwiseBlock {
+		sum := 0.0
+		for i := lo; i < hi; i++ {
+			sum += term(i)
+		}
+		return sum
+	}
+
+	mid := lo + (hi-lo)/2
+	return pairwiseSum(lo, mid, term) + pairwiseSum(mid, hi, term)
+}
@@ -2,28 +2,14 @@ package tools
 
 import "math"
 
-// DotProduct Скалярное произведение двух векторов
+// DotProduct Скалярное произведение двух векторов (с компенсированным суммированием Ноймайера)
 func DotProduct(vector1, vector2 []float64) float64 {
-	if len(vector1) != len(vector2) {
-		panic("Длины векторов должны совпадать")
-	}
-
-	result := 0.0
-	for i := 0; i < len(vector1); i++ {
-		result += vector1[i] * vector2[i]
-	}
-
-	return result
+	return DotProductSum(vector1, vector2, NeumaierSum)
 }
 
-// EuclideanNorm Евклидова норма вектора
+// EuclideanNorm Евклидова норма вектора (с компенсированным суммированием Ноймайера)
 func EuclideanNorm(vector []float64) float64 {
-	result := 0.0
-	for i := 0; i < len(vector); i++ {
-		result += vector[i] * vector[i]
-	}
-
-	return math.Sqrt(result)
+	return EuclideanNormSum(vector, NeumaierSum)
 }
 
 // SubtractVectors Вычитание двух векторов
@@ -70,15 +56,17 @@ func MultiplyMatrices(matrix1, matrix2 [][]float64) [][]float64 {
 }
 
 // MatrixNorm
-// кубическая/строковая норма матрицы
+// кубическая/строковая норма матрицы (суммы строк вычисляются с компенсированным суммированием Ноймайера)
 func MatrixNorm(matrix [][]float64) float64 {
+	return MatrixNormSum(matrix, NeumaierSum)
+}
+
+// MatrixNormSum кубическая/строковая норма матрицы, модули элементов строк складываются способом s
+func MatrixNormSum(matrix [][]float64, s Summation) float64 {
 	var maxSum float64
 
 	for i := range matrix {
-		var sum float64
-		for j := range matrix[0] {
-			sum += math.Abs(matrix[i][j])
-		}
+		sum := sumTerms(len(matrix[0]), func(j int) float64 { return math.Abs(matrix[i][j]) }, s)
 		if sum > maxSum {
 			maxSum = sum
 		}
@@ -182,15 +170,67 @@ func FindMaxOffDiagonalElement(A [][]float64) (int, int) {
 	return p, q
 }
 
-// Off возвращает сумму квадратов элементов вне главной диагонали матрицы.
+// Off возвращает сумму квадратов элементов вне главной диагонали матрицы (с компенсированным суммированием).
 func Off(A [][]float64) float64 {
-	maxVal := 0.0
+	return OffSum(A, NeumaierSum)
+}
 
+// OffSum возвращает сумму квадратов элементов вне главной диагонали матрицы, квадраты складываются способом s
+func OffSum(A [][]float64, s Summation) float64 {
+	var terms []float64
 	for i := 0; i < len(A)-1; i++ {
 		for j := i + 1; j < len(A); j++ {
-			maxVal += 2 * A[i][j] * A[i][j]
+			terms = append(terms, 2*A[i][j]*A[i][j])
 		}
 	}
 
-	return maxVal
+	return Sum(terms, s)
+}
+
+// IdentityMatrix возвращает единичную матрицу размера n.
+func IdentityMatrix(n int) [][]float64 {
+	I := make([][]float64, n)
+	for i := range I {
+		I[i] = make([]float64, n)
+		I[i][i] = 1
+	}
+
+	return I
+}
+
+// IsSymmetric проверяет симметричность квадратной матрицы с относительной точностью tol.
+func IsSymmetric(A [][]float64, tol float64) bool {
+	for i := range A {
+		if len(A[i]) != len(A) {
+			return false
+		}
+	}
+
+	norm := MatrixNorm(A)
+	for i := 0; i < len(A); i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(A[i][j]-A[j][i]) > tol*norm {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// AddMatrices складывает две матрицы одинакового размера.
+func AddMatrices(matrix1, matrix2 [][]float64) [][]float64 {
+	if len(matrix1) != len(matrix2) || len(matrix1[0]) != len(matrix2[0]) {
+		panic("Размеры матриц должны совпадать")
+	}
+
+	result := make([][]float64, len(matrix1))
+	for i := range result {
+		result[i] = make([]float64, len(matrix1[i]))
+		for j := range result[i] {
+			result[i][j] = matrix1[i][j] + matrix2[i][j]
+		}
+	}
+
+	return result
 }